@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/arttor/helmify/pkg/config"
@@ -50,9 +51,66 @@ func (i *arrayFlags) Set(value string) error {
 	return nil
 }
 
+// listFlag - collects comma-separated values. Can be set multiple times.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// mapFlag - collects comma-separated key=value pairs. Can be set multiple times.
+type mapFlag map[string]string
+
+func (l mapFlag) String() string {
+	if len(l) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(l))
+	for k, v := range l {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (l mapFlag) Set(value string) error {
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return fmt.Errorf("invalid pair %q: expected key=value", pair)
+		}
+		l[k] = strings.TrimSpace(v)
+	}
+	return nil
+}
+
 // ReadFlags command-line flags into app config.
 func ReadFlags() config.Config {
 	files := arrayFlags{}
+	removePrefixes := arrayFlags{}
+	stripMetadata := arrayFlags{}
+	postRender := arrayFlags{}
+	onlyKinds := listFlag{}
+	skipKinds := listFlag{}
+	capabilitiesKinds := listFlag{}
+	commonLabels := mapFlag{}
+	commonAnnotations := mapFlag{}
+	featureGroups := mapFlag{}
+	apiVersions := mapFlag{}
+	valuesFromEnv := mapFlag{}
 	result := config.Config{}
 	var h, help, version, crd bool
 	flag.BoolVar(&h, "h", false, "Print help. Example: helmify -h")
@@ -61,12 +119,56 @@ func ReadFlags() config.Config {
 	flag.BoolVar(&result.Verbose, "v", false, "Enable verbose output (print WARN & INFO). Example: helmify -v")
 	flag.BoolVar(&result.VeryVerbose, "vv", false, "Enable very verbose output. Same as verbose but with DEBUG. Example: helmify -vv")
 	flag.BoolVar(&crd, "crd-dir", false, "Enable crd install into 'crds' directory.\nWarning: CRDs placed in 'crds' directory will not be templated by Helm.\nSee https://helm.sh/docs/chart_best_practices/custom_resource_definitions/#some-caveats-and-explanations\nExample: helmify -crd-dir")
-	flag.BoolVar(&result.ImagePullSecrets, "image-pull-secrets", false, "Allows the user to use existing secrets as imagePullSecrets in values.yaml")
+	flag.BoolVar(&result.ImagePullSecrets, "image-pull-secrets", false, "Add chart-wide imagePullSecrets value to every pod, merged with pod image pull secrets from the source. Example: helmify -image-pull-secrets")
+	flag.BoolVar(&result.GlobalValues, "global-values", false, "Hoist shared image registry, imagePullSecrets, storageClass and labels values to global block referenced with fallback to per-resource values, e.g. for umbrella charts. Implies -global-image-registry. Example: helmify -global-values")
+	flag.BoolVar(&result.GlobalImageRegistry, "global-image-registry", false, "Prepend overridable global.imageRegistry value to all container images, e.g. for air-gapped installs. Example: helmify -global-image-registry")
+	flag.BoolVar(&result.PrefixClassNames, "prefix-class-names", false, "Prefix PriorityClass and RuntimeClass names with chart fullname to avoid cluster-wide name collisions. Example: helmify -prefix-class-names")
+	flag.BoolVar(&result.ReleasePrefixClusterScoped, "release-prefix-cluster-scoped", false, "Prefix names of cluster-scoped resources, e.g. ClusterRoles, with release name to allow multiple installs in one cluster. Namespaced resources are not changed. Example: helmify -release-prefix-cluster-scoped")
+	flag.BoolVar(&result.DecodeSecrets, "decode-secrets", false, "Put decoded text values of Opaque secrets to values.yaml as plaintext defaults. Values are base64 encoded on render. Example: helmify -decode-secrets")
 	flag.BoolVar(&result.GenerateDefaults, "generate-defaults", false, "Allows the user to add empty placeholders for tipical customization options in values.yaml. Currently covers: topology constraints, node selectors, tolerances")
 	flag.BoolVar(&result.CertManagerAsSubchart, "cert-manager-as-subchart", false, "Allows the user to add cert-manager as a subchart")
 	flag.StringVar(&result.CertManagerVersion, "cert-manager-version", "v1.12.2", "Allows the user to specify cert-manager subchart version. Only useful with cert-manager-as-subchart.")
+	flag.BoolVar(&result.GenerateReadme, "generate-readme", false, "Generate chart README.md with a table of all values, their types, defaults and source templates. Example: helmify -generate-readme")
+	flag.BoolVar(&result.HelmTests, "helm-tests", false, "Generate Helm test Pod in templates/tests checking connectivity to every chart Service not wrapped into feature or Capabilities guard, run with 'helm test'. Example: helmify -helm-tests")
+	flag.BoolVar(&result.ValidateChart, "validate", false, "Render generated chart with Helm and report rendering errors. Example: helmify -validate")
+	flag.BoolVar(&result.Lint, "lint", false, "Print warnings about common anti-patterns in input manifests: latest image tags, missing resource limits, hardcoded namespaces. Example: helmify -lint")
+	flag.BoolVar(&result.Strict, "strict", false, "Fail on lossy conversions, e.g. dropped config data, pod spec fields or resources without processor, instead of printing warnings. Example: helmify -strict")
+	flag.StringVar(&result.DefaultsFile, "defaults-file", "", "Yaml file with values deep merged over extracted values.yaml defaults. Templates are not changed. Example: helmify -defaults-file ./defaults.yaml")
+	flag.Var(valuesFromEnv, "set-from-env", "Comma-separated key=ENV_VAR pairs setting values.yaml defaults from environment variables at generation time, keys are dot-separated values paths. Can be set multiple times. Example: helmify -set-from-env web.nginx.image.tag=CI_TAG")
+	flag.StringVar(&result.OutputFormat, "output-format", config.OutputFormatDir, "Chart output format: 'dir' writes chart files only, 'bundle' also prints the whole chart to stdout as a single yaml stream, 'kustomize' writes Kustomize base and overlay instead of a chart, 'patch' prints JSON6902 patch of changes to existing chart without writing it. Example: helmify -output-format bundle")
+	flag.BoolVar(&result.ConfigMapDataBlock, "configmap-data-block", false, "Lift the whole data of every ConfigMap to a single <name>.data values map, so it can be overridden at once. Example: helmify -configmap-data-block")
+	flag.BoolVar(&result.ContainerPortValues, "container-port-values", false, "Lift container ports to <name>.<container>.ports.<portName> integer values, unnamed ports are lifted by index, e.g. port0. Example: helmify -container-port-values")
+	flag.BoolVar(&result.NestContainerValues, "nest-container-values", false, "Key values of init and ephemeral containers under <name>.initContainers.<container> and <name>.ephemeralContainers.<container> to avoid collisions with main containers. Example: helmify -nest-container-values")
+	flag.BoolVar(&result.GroupManagerConfig, "group-manager-config", false, "Lift leaderElection, metrics, webhook and health settings of ControllerManagerConfig in ConfigMaps to top level values, e.g. leaderElection.leaderElect. Example: helmify -group-manager-config")
+	flag.BoolVar(&result.NoLabels, "no-labels", false, "Do not add chart labels helper include to resources, keep only labels from the source manifests. Example: helmify -no-labels")
+	flag.IntVar(&result.IndentWidth, "indent", 0, "Indentation width of generated templates and values.yaml, from 2 to 8. Default is 2. Example: helmify -indent 4")
+	flag.StringVar(&result.ChartMetadataFile, "chart-metadata", "", "Yaml file with Chart.yaml fields added to generated Chart.yaml, e.g. maintainers, home, sources and annotations like artifacthub.io/changes. Example: helmify -chart-metadata ./chart-metadata.yaml")
+	flag.StringVar(&result.LicenseHeaderFile, "license-header", "", "File with header prepended to every generated yaml file as a comment. Example: helmify -license-header ./hack/boilerplate.yaml.txt")
+	flag.BoolVar(&result.AssumeYes, "yes", false, "Overwrite existing chart files without confirmation prompt. Example: helmify -yes")
+	flag.BoolVar(&result.Force, "force", false, "Confirm overwriting existing chart files when stdin is not a terminal, e.g. manifests are piped or in CI, without a warning. Example: cat my-app.yaml | helmify -force mychart")
 	flag.BoolVar(&result.FilesRecursively, "r", false, "Scan dirs from -f option recursively")
+	flag.StringVar(&result.OCIRef, "oci", "", "Reference of OCI artifact with k8s manifests pulled instead of reading -f or stdin, registry credentials are taken from docker config. Example: helmify -oci ghcr.io/org/manifests:1.0.0")
+	flag.BoolVar(&result.OCIPlainHTTP, "oci-plain-http", false, "Pull OCI artifact set with -oci over plain HTTP, e.g. from local registry. Example: helmify -oci localhost:5000/manifests:1.0.0 -oci-plain-http")
 	flag.Var(&files, "f", "File or directory containing k8s manifests")
+	flag.BoolVar(&result.StripHashSuffix, "strip-hash-suffix", false, "Remove content hash suffixes added by kustomize configMapGenerator and secretGenerator from ConfigMap and Secret names, e.g. my-config-7fmb6gk9t4. Example: helmify -strip-hash-suffix")
+	flag.BoolVar(&result.TrimSuffix, "trim-suffix", false, "Detect common suffix of all resource names, e.g. added by kustomize nameSuffix, and trim it. Example: helmify -trim-suffix")
+	flag.Var(&removePrefixes, "remove-prefix", "Prefix to trim from all resource names instead of detected common prefix. Can be set multiple times, applied in order. Example: helmify -remove-prefix myoperator-")
+	flag.Var(&stripMetadata, "strip-metadata", "Label or annotation key to remove from all objects in addition to defaults, e.g. kubectl.kubernetes.io/last-applied-configuration. Can be set multiple times. Example: helmify -strip-metadata argocd.argoproj.io/instance")
+	flag.Var(apiVersions, "api-version", "Comma-separated Kind=apiVersion pairs pinning apiVersion of all resources of the kind instead of the source one. Can be set multiple times. Example: helmify -api-version Deployment=apps/v1")
+	flag.BoolVar(&result.ValuesAnchors, "values-anchors", false, "Write repeated structures of values.yaml once with yaml anchor and reference them with aliases, e.g. identical container resources. Example: helmify -values-anchors")
+	flag.BoolVar(&result.SplitValues, "split-values", false, "In addition to values.yaml, write values of every template file to values/<template file>, e.g. values/deployment.yaml, to be used with helm -f. Example: helmify -split-values")
+	flag.BoolVar(&result.ExtractValues, "extract-values", false, "Scan templates of existing chart for .Values references and add stubs for values missing in its values.yaml instead of processing input. Example: helmify -extract-values mychart")
+	flag.BoolVar(&result.VerboseFilenames, "verbose-filenames", false, "Prefix generated template filenames with object kind and apiVersion for debugging, e.g. deployment-apps-v1-myapp.yaml. Example: helmify -verbose-filenames")
+	flag.BoolVar(&result.PreserveOrder, "preserve-order", false, "Keep input documents order instead of sorting by kind and prefix generated template filenames with numbers in this order, e.g. 01-myapp.yaml. Example: helmify -preserve-order")
+	flag.BoolVar(&result.KeepPSP, "keep-psp", false, "Keep deprecated PodSecurityPolicy objects in the chart, e.g. for clusters older than 1.25. By default they are dropped with a warning suggesting Pod Security Standard level. Example: helmify -keep-psp")
+	flag.Var(&onlyKinds, "only-kinds", "Comma-separated kinds of objects added to the chart, other objects are skipped. Can't be used with -skip-kinds. Example: helmify -only-kinds Deployment,Service")
+	flag.Var(&skipKinds, "skip-kinds", "Comma-separated kinds of objects not added to the chart. Can't be used with -only-kinds. Example: helmify -skip-kinds CustomResourceDefinition")
+	flag.Var(&capabilitiesKinds, "capabilities-kinds", "Comma-separated kinds of objects installed only if the cluster serves their apiVersion, checked with .Capabilities.APIVersions.Has. Example: helmify -capabilities-kinds ServiceMonitor,PodMonitor")
+	flag.Var(featureGroups, "feature-groups", "Comma-separated name=group pairs of objects installed only if .Values.<group>.enabled is true. Can be set multiple times. Example: helmify -feature-groups my-webhook-service=webhooks,my-serving-cert=webhooks")
+	flag.StringVar(&result.FeatureLabel, "feature-label", "", "Label key with feature group of objects installed only if .Values.<group>.enabled is true. Example: helmify -feature-label app.kubernetes.io/component")
+	flag.Var(&postRender, "post-render", "Shell command every generated file is piped through before it is written, file name is set in HELMIFY_FILE env. Can be set multiple times. Example: helmify -post-render 'sed s/foo/bar/'")
+	flag.Var(commonLabels, "add-common-labels", "Comma-separated key=value labels added to every chart resource via the labels helper. Example: helmify -add-common-labels team=payments,cost-center=42")
+	flag.Var(commonAnnotations, "add-common-annotations", "Comma-separated key=value annotations added to every chart resource via the annotations helper. Resource annotations with the same key are kept. Example: helmify -add-common-annotations owner=payments")
 
 	flag.Parse()
 	if h || help {
@@ -87,5 +189,26 @@ func ReadFlags() config.Config {
 		result.Crd = crd
 	}
 	result.Files = files
+	result.RemovePrefixes = removePrefixes
+	result.StripMetadata = stripMetadata
+	result.PostRenderCommands = postRender
+	result.OnlyKinds = onlyKinds
+	result.SkipKinds = skipKinds
+	result.CapabilitiesKinds = capabilitiesKinds
+	if len(featureGroups) != 0 {
+		result.FeatureGroups = featureGroups
+	}
+	if len(commonLabels) != 0 {
+		result.CommonLabels = commonLabels
+	}
+	if len(commonAnnotations) != 0 {
+		result.CommonAnnotations = commonAnnotations
+	}
+	if len(valuesFromEnv) != 0 {
+		result.ValuesFromEnv = valuesFromEnv
+	}
+	if len(apiVersions) != 0 {
+		result.APIVersions = apiVersions
+	}
 	return result
 }
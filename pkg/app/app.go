@@ -8,8 +8,15 @@ import (
 	"syscall"
 
 	"github.com/arttor/helmify/pkg/file"
+	"github.com/arttor/helmify/pkg/oci"
+	"github.com/arttor/helmify/pkg/processor/argo"
+	"github.com/arttor/helmify/pkg/processor/autoscaling"
+	"github.com/arttor/helmify/pkg/processor/coordination"
 	"github.com/arttor/helmify/pkg/processor/job"
+	"github.com/arttor/helmify/pkg/processor/monitoring"
+	"github.com/arttor/helmify/pkg/processor/openshift"
 	"github.com/arttor/helmify/pkg/processor/poddisruptionbudget"
+	"github.com/arttor/helmify/pkg/processor/podsecurity"
 	"github.com/arttor/helmify/pkg/processor/statefulset"
 
 	"github.com/sirupsen/logrus"
@@ -22,10 +29,13 @@ import (
 	"github.com/arttor/helmify/pkg/processor/crd"
 	"github.com/arttor/helmify/pkg/processor/daemonset"
 	"github.com/arttor/helmify/pkg/processor/deployment"
+	"github.com/arttor/helmify/pkg/processor/gateway"
 	"github.com/arttor/helmify/pkg/processor/rbac"
+	"github.com/arttor/helmify/pkg/processor/scheduling"
 	"github.com/arttor/helmify/pkg/processor/secret"
 	"github.com/arttor/helmify/pkg/processor/service"
 	"github.com/arttor/helmify/pkg/processor/storage"
+	"github.com/arttor/helmify/pkg/processor/traefik"
 	"github.com/arttor/helmify/pkg/processor/webhook"
 )
 
@@ -36,6 +46,9 @@ func Start(stdin io.Reader, config config.Config) error {
 		return err
 	}
 	setLogLevel(config)
+	if config.ExtractValues {
+		return helm.ExtractValues(config)
+	}
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
 	done := make(chan os.Signal, 1)
@@ -49,12 +62,16 @@ func Start(stdin io.Reader, config config.Config) error {
 	appCtx = appCtx.WithProcessors(
 		configmap.New(),
 		crd.New(),
+		crd.NewCustomResource(),
 		daemonset.New(),
 		deployment.New(),
 		statefulset.New(),
 		storage.New(),
 		service.New(),
 		service.NewIngress(),
+		service.NewIngressClass(),
+		gateway.New(),
+		gateway.NewHTTPRoute(),
 		rbac.ClusterRoleBinding(),
 		rbac.Role(),
 		rbac.RoleBinding(),
@@ -67,15 +84,42 @@ func Start(stdin io.Reader, config config.Config) error {
 		job.NewCron(),
 		job.NewJob(),
 		poddisruptionbudget.New(),
+		podsecurity.NewPodSecurityPolicy(),
+		autoscaling.NewVerticalPodAutoscaler(),
+		scheduling.NewPriorityClass(),
+		scheduling.NewRuntimeClass(),
+		coordination.NewLease(),
+		monitoring.NewPodMonitor(),
+		monitoring.NewPrometheusRule(),
+		openshift.NewRoute(),
+		openshift.NewDeploymentConfig(),
+		argo.NewRollout(),
+		argo.NewApplication(),
+		traefik.NewIngressRoute(),
 	).WithDefaultProcessor(processor.Default())
-	if len(config.Files) != 0 {
+	switch {
+	case config.OCIRef != "":
+		err = oci.Walk(ctx, config.OCIRef, config.OCIPlainHTTP, func(_ string, layer io.Reader) {
+			objects := decoder.Decode(ctx.Done(), layer)
+			for obj := range objects {
+				appCtx.Add(obj, "")
+			}
+		})
+		if err != nil {
+			return err
+		}
+	case len(config.Files) != 0:
 		file.Walk(config.Files, config.FilesRecursively, func(filename string, fileReader io.Reader) {
+			if file.IsArchive(filename) {
+				// archive objects are named by processors.
+				filename = ""
+			}
 			objects := decoder.Decode(ctx.Done(), fileReader)
 			for obj := range objects {
 				appCtx.Add(obj, filename)
 			}
 		})
-	} else {
+	default:
 		objects := decoder.Decode(ctx.Done(), stdin)
 		for obj := range objects {
 			appCtx.Add(obj, "")
@@ -87,6 +131,10 @@ func Start(stdin io.Reader, config config.Config) error {
 
 func setLogLevel(config config.Config) {
 	logrus.SetLevel(logrus.ErrorLevel)
+	if config.Lint {
+		// lint findings are reported as warnings.
+		logrus.SetLevel(logrus.WarnLevel)
+	}
 	if config.Verbose {
 		logrus.SetLevel(logrus.InfoLevel)
 	}
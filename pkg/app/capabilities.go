@@ -0,0 +1,42 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// capabilitiesGuard - wraps template to be installed only if the cluster serves its API, e.g. ServiceMonitor only
+// if Prometheus operator CRDs are installed.
+const capabilitiesGuard = `{{- if .Capabilities.APIVersions.Has %q }}
+%s
+{{- end }}`
+
+// capabilitiesTemplate - template wrapped into Capabilities check of its API.
+type capabilitiesTemplate struct {
+	helmify.Template
+	// api - checked API in group/version/kind form, e.g. monitoring.coreos.com/v1/ServiceMonitor.
+	api string
+}
+
+// withCapabilities - wraps template of object with given kind into Capabilities check of the apiVersion
+// rendered in the template: the one pinned for the kind in config or the source one.
+func (c *appContext) withCapabilities(template helmify.Template, gvk schema.GroupVersionKind) helmify.Template {
+	apiVersion, ok := c.config.APIVersions[gvk.Kind]
+	if !ok {
+		apiVersion = gvk.GroupVersion().String()
+	}
+	return &capabilitiesTemplate{Template: template, api: apiVersion + "/" + gvk.Kind}
+}
+
+func (t *capabilitiesTemplate) Write(writer io.Writer) error {
+	var buf bytes.Buffer
+	if err := t.Template.Write(&buf); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, capabilitiesGuard, t.api, bytes.TrimRight(buf.Bytes(), "\n"))
+	return err
+}
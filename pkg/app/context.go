@@ -1,11 +1,19 @@
 package app
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/arttor/helmify/pkg/config"
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/kustomize"
+	"github.com/arttor/helmify/pkg/lint"
 	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/service"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // appContext helm processing context. Stores processed objects.
@@ -17,14 +25,16 @@ type appContext struct {
 	appMeta          *metadata.Service
 	objects          []*unstructured.Unstructured
 	fileNames        []string
+	stripKeys        []string
 }
 
 // New returns context with config set.
 func New(config config.Config, output helmify.Output) *appContext {
 	return &appContext{
-		config:  config,
-		appMeta: metadata.New(config),
-		output:  output,
+		config:    config,
+		appMeta:   metadata.New(config),
+		output:    output,
+		stripKeys: append(append([]string{}, defaultStripKeys...), config.StripMetadata...),
 	}
 }
 
@@ -42,6 +52,15 @@ func (c *appContext) WithDefaultProcessor(processor helmify.Processor) *appConte
 
 // Add k8s object to app context.
 func (c *appContext) Add(obj *unstructured.Unstructured, filename string) {
+	if skipKind(c.config, obj.GetKind()) {
+		logrus.WithFields(logrus.Fields{
+			"Kind": obj.GetKind(),
+			"Name": obj.GetName(),
+		}).Debug("skipped by kind filter")
+		return
+	}
+	stripHelmMeta(obj)
+	stripMetadata(obj.Object, c.stripKeys)
 	// we need to add all objects before start processing only to define app metadata.
 	c.appMeta.Load(obj)
 	c.objects = append(c.objects, obj)
@@ -54,19 +73,57 @@ func (c *appContext) CreateHelm(stop <-chan struct{}) error {
 		"ChartName": c.appMeta.ChartName(),
 		"Namespace": c.appMeta.Namespace(),
 	}).Info("creating a chart")
+	if !c.config.PreserveOrder {
+		sortByKind(c.objects, c.fileNames)
+	}
+	if c.config.Lint {
+		for _, w := range lint.Check(c.appMeta, c.objects) {
+			logrus.WithFields(logrus.Fields{
+				"Kind": w.Kind,
+				"Name": w.Name,
+			}).Warn(w.Message)
+		}
+	}
+	if c.config.OutputFormat == config.OutputFormatKustomize {
+		return kustomize.Create(c.config, c.appMeta, c.objects)
+	}
 	var templates []helmify.Template
 	var filenames []string
+	// lossy conversion errors are collected to report all of them at once in strict mode.
+	var lossyErrs []error
+	// service names are taken beforehand as processors may change the objects.
+	services := c.testedServices()
+	ordered := newOrderedFilenames(len(c.objects))
 	for i, obj := range c.objects {
+		// processors may change the object, so its kind is taken beforehand.
+		gvk := obj.GroupVersionKind()
+		feature := c.feature(obj)
 		template, err := c.process(obj)
+		if errors.Is(err, processor.ErrLossyConversion) {
+			lossyErrs = append(lossyErrs, err)
+			continue
+		}
 		if err != nil {
 			return err
 		}
 		if template != nil {
+			if containsKind(c.config.CapabilitiesKinds, gvk.Kind) {
+				template = c.withCapabilities(template, gvk)
+			}
+			if feature != "" {
+				template = withFeature(template, feature)
+			}
 			templates = append(templates, template)
 			filename := template.Filename()
 			if c.fileNames[i] != "" {
 				filename = c.fileNames[i]
 			}
+			if c.config.VerboseFilenames {
+				filename = verboseFilename(gvk, filename)
+			}
+			if c.config.PreserveOrder {
+				filename = ordered.name(filename)
+			}
 			filenames = append(filenames, filename)
 		}
 		select {
@@ -75,7 +132,49 @@ func (c *appContext) CreateHelm(stop <-chan struct{}) error {
 		default:
 		}
 	}
-	return c.output.Create(c.config.ChartDir, c.config.ChartName, c.config.Crd, c.config.CertManagerAsSubchart, c.config.CertManagerVersion , templates, filenames)
+	if len(lossyErrs) != 0 {
+		return errors.Join(lossyErrs...)
+	}
+	if c.config.HelmTests {
+		if test := service.ConnectionTest(c.appMeta, services); test != nil {
+			templates = append(templates, test)
+			filenames = append(filenames, test.Filename())
+		}
+	}
+	return c.output.Create(c.config, templates, filenames)
+}
+
+// testedServices - returns names of Services with cluster IP or node ports, i.e. reachable for connection test.
+// Services wrapped into feature toggle or Capabilities check are skipped as they may be not installed.
+func (c *appContext) testedServices() []string {
+	if containsKind(c.config.CapabilitiesKinds, "Service") {
+		return nil
+	}
+	var res []string
+	for _, obj := range c.objects {
+		if obj.GetAPIVersion() != "v1" || obj.GetKind() != "Service" {
+			continue
+		}
+		if svcType, _, _ := unstructured.NestedString(obj.Object, "spec", "type"); svcType == "ExternalName" {
+			continue
+		}
+		if c.feature(obj) != "" {
+			continue
+		}
+		res = append(res, obj.GetName())
+	}
+	return res
+}
+
+// verboseFilename - returns template filename prefixed with object kind, API group and version, e.g.
+// deployment-apps-v1-myapp.yaml or service-v1-myapp.yaml for core group.
+func verboseFilename(gvk schema.GroupVersionKind, filename string) string {
+	parts := []string{strings.ToLower(gvk.Kind)}
+	if gvk.Group != "" {
+		parts = append(parts, gvk.Group)
+	}
+	parts = append(parts, gvk.Version, filename)
+	return strings.Join(parts, "-")
 }
 
 func (c *appContext) process(obj *unstructured.Unstructured) (helmify.Template, error) {
@@ -93,12 +192,7 @@ func (c *appContext) process(obj *unstructured.Unstructured) (helmify.Template,
 		}
 	}
 	if c.defaultProcessor == nil {
-		logrus.WithFields(logrus.Fields{
-			"ApiVersion": obj.GetAPIVersion(),
-			"Kind":       obj.GetKind(),
-			"Name":       obj.GetName(),
-		}).Warn("Skipping: no suitable processor for resource.")
-		return nil, nil
+		return nil, processor.ReportLossy(c.appMeta, obj, "Skipping: no suitable processor for resource.")
 	}
 	_, t, err := c.defaultProcessor.Process(c.appMeta, obj)
 	return t, err
@@ -0,0 +1,185 @@
+package app
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_appContext_CreateHelm(t *testing.T) {
+	t.Run("strict mode collects lossy errors", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", Strict: true}, out)
+		ctx.Add(internal.GenerateObj("apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: a"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: example.com/v1\nkind: Bar\nmetadata:\n  name: b"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "Foo a")
+			assert.Contains(t, err.Error(), "Bar b")
+		}
+		assert.Nil(t, out.filenames)
+	})
+	t.Run("strict mode passes unsupported resources through default processor", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", Strict: true}, out).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: a"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.yaml"}, out.filenames)
+	})
+	t.Run("not strict", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart"}, out).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: a"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"a.yaml"}, out.filenames)
+	})
+	t.Run("verbose filenames", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", VerboseFilenames: true}, out).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: myapp"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: source"), "source.yaml")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"configmap-v1-source.yaml", "service-v1-myapp.yaml", "deployment-apps-v1-myapp.yaml"}, out.filenames)
+	})
+	t.Run("preserve order", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", PreserveOrder: true}, out).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: myapp-web"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-svc"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myapp-config"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"01-web.yaml", "02-svc.yaml", "03-config.yaml"}, out.filenames)
+	})
+	t.Run("capabilities kinds", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", CapabilitiesKinds: []string{"ServiceMonitor"}}, out).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: monitoring.coreos.com/v1\nkind: ServiceMonitor\nmetadata:\n  name: metrics"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: metrics"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		if assert.Len(t, out.templates, 2) {
+			var monitor, service bytes.Buffer
+			assert.NoError(t, out.templates[0].Write(&service))
+			assert.NoError(t, out.templates[1].Write(&monitor))
+			assert.NotContains(t, service.String(), "Capabilities")
+			assert.True(t, strings.HasPrefix(monitor.String(),
+				"{{- if .Capabilities.APIVersions.Has \"monitoring.coreos.com/v1/ServiceMonitor\" }}\napiVersion: monitoring.coreos.com/v1\nkind: ServiceMonitor\n"), monitor.String())
+			assert.True(t, strings.HasSuffix(monitor.String(), "\n{{- end }}"), monitor.String())
+		}
+	})
+	t.Run("capabilities kinds with pinned apiVersion", func(t *testing.T) {
+		out := &outputMock{}
+		conf := config.Config{
+			ChartName:         "chart",
+			CapabilitiesKinds: []string{"PodDisruptionBudget"},
+			APIVersions:       map[string]string{"PodDisruptionBudget": "policy/v1"},
+		}
+		ctx := New(conf, out).WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: policy/v1beta1\nkind: PodDisruptionBudget\nmetadata:\n  name: pdb"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		if assert.Len(t, out.templates, 1) {
+			var buf bytes.Buffer
+			assert.NoError(t, out.templates[0].Write(&buf))
+			assert.Contains(t, buf.String(), `{{- if .Capabilities.APIVersions.Has "policy/v1/PodDisruptionBudget" }}`)
+		}
+	})
+	t.Run("feature groups", func(t *testing.T) {
+		out := &outputMock{}
+		conf := config.Config{
+			ChartName:     "chart",
+			FeatureLabel:  "app.kubernetes.io/component",
+			FeatureGroups: map[string]string{"serving-cert": "webhooks"},
+		}
+		ctx := New(conf, out).WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj("apiVersion: admissionregistration.k8s.io/v1\nkind: ValidatingWebhookConfiguration\nmetadata:\n  name: validating-webhook\n  labels:\n    app.kubernetes.io/component: webhooks"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: webhook-service\n  labels:\n    app.kubernetes.io/component: webhooks"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: cert-manager.io/v1\nkind: Certificate\nmetadata:\n  name: serving-cert"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		if assert.Len(t, out.templates, 4) {
+			var guarded int
+			for _, template := range out.templates {
+				var buf bytes.Buffer
+				assert.NoError(t, template.Write(&buf))
+				if !strings.Contains(buf.String(), "kind: ConfigMap") {
+					guarded++
+					assert.True(t, strings.HasPrefix(buf.String(), "{{- if .Values.webhooks.enabled }}\napiVersion: "), buf.String())
+					assert.True(t, strings.HasSuffix(buf.String(), "\n{{- end }}"), buf.String())
+					assert.Equal(t, map[string]interface{}{"enabled": true}, template.Values()["webhooks"])
+				} else {
+					assert.NotContains(t, buf.String(), ".enabled")
+				}
+			}
+			assert.Equal(t, 3, guarded)
+		}
+	})
+	t.Run("helm tests", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", HelmTests: true}, out).
+			WithProcessors(service.New())
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\nspec:\n  ports:\n  - port: 80"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"web.yaml", "tests/test-connection.yaml"}, out.filenames)
+		if assert.Len(t, out.templates, 2) {
+			var buf bytes.Buffer
+			assert.NoError(t, out.templates[1].Write(&buf))
+			assert.Contains(t, buf.String(), "helm.sh/hook: test")
+			assert.Contains(t, buf.String(), `'{{ include "chart.fullname" . }}-web', '{{ (index .Values.web.ports 0).port }}'`)
+		}
+	})
+	t.Run("helm tests skip guarded services", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart", HelmTests: true, FeatureGroups: map[string]string{"webhook-service": "webhooks"}}, out).
+			WithProcessors(service.New())
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\nspec:\n  ports:\n  - port: 80"), "")
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: webhook-service\nspec:\n  ports:\n  - port: 443"), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		if assert.Len(t, out.templates, 3) {
+			var buf bytes.Buffer
+			assert.NoError(t, out.templates[2].Write(&buf))
+			assert.Contains(t, buf.String(), "-web',")
+			assert.NotContains(t, buf.String(), "webhook-service")
+		}
+
+		out = &outputMock{}
+		ctx = New(config.Config{ChartName: "chart", HelmTests: true, CapabilitiesKinds: []string{"Service"}}, out).
+			WithProcessors(service.New())
+		ctx.Add(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: web\nspec:\n  ports:\n  - port: 80"), "")
+
+		err = ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"web.yaml"}, out.filenames)
+	})
+}
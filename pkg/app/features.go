@@ -0,0 +1,61 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/iancoleman/strcase"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// featureGuard - wraps template to be installed only if its feature group is enabled in values.
+const featureGuard = `{{- if .Values.%s.enabled }}
+%s
+{{- end }}`
+
+// featureTemplate - template wrapped into toggle of its feature group.
+type featureTemplate struct {
+	helmify.Template
+	// feature - values key of the feature group, e.g. webhooks.
+	feature string
+}
+
+// feature - returns feature group of given object: the one set for the object name in config or the value of
+// feature label. Returns empty string if the object belongs to no group.
+func (c *appContext) feature(obj *unstructured.Unstructured) string {
+	if feature, ok := c.config.FeatureGroups[obj.GetName()]; ok {
+		return feature
+	}
+	if c.config.FeatureLabel == "" {
+		return ""
+	}
+	return obj.GetLabels()[c.config.FeatureLabel]
+}
+
+// withFeature - wraps template into .Values.<feature>.enabled toggle enabled by default.
+func withFeature(template helmify.Template, feature string) helmify.Template {
+	return &featureTemplate{Template: template, feature: strcase.ToLowerCamel(feature)}
+}
+
+func (t *featureTemplate) Values() helmify.Values {
+	values := t.Template.Values()
+	if values == nil {
+		values = helmify.Values{}
+	}
+	if err := unstructured.SetNestedField(values, true, t.feature, "enabled"); err != nil {
+		logrus.WithField("feature", t.feature).WithError(err).Warn("unable to set feature toggle value")
+	}
+	return values
+}
+
+func (t *featureTemplate) Write(writer io.Writer) error {
+	var buf bytes.Buffer
+	if err := t.Template.Write(&buf); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, featureGuard, t.feature, bytes.TrimRight(buf.Bytes(), "\n"))
+	return err
+}
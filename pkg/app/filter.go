@@ -0,0 +1,25 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+)
+
+// skipKind - reports whether objects of given kind are excluded from the chart by config.OnlyKinds or
+// config.SkipKinds. Kinds are compared case-insensitively.
+func skipKind(conf config.Config, kind string) bool {
+	if len(conf.OnlyKinds) != 0 {
+		return !containsKind(conf.OnlyKinds, kind)
+	}
+	return containsKind(conf.SkipKinds, kind)
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
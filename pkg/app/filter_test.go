@@ -0,0 +1,61 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/crd"
+	"github.com/arttor/helmify/pkg/processor/deployment"
+	"github.com/arttor/helmify/pkg/processor/rbac"
+	"github.com/stretchr/testify/assert"
+)
+
+const filterCRDYaml = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: cronjobs.batch.tutorial.kubebuilder.io
+spec:
+  group: batch.tutorial.kubebuilder.io
+  names:
+    kind: CronJob
+    listKind: CronJobList
+    plural: cronjobs
+    singular: cronjob
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true`
+
+func Test_appContext_kindFilter(t *testing.T) {
+	newCtx := func(conf config.Config, out *outputMock) *appContext {
+		conf.ChartName = "chart"
+		ctx := New(conf, out).
+			WithProcessors(crd.New(), deployment.New(), rbac.ServiceAccount()).
+			WithDefaultProcessor(processor.Default())
+		ctx.Add(internal.GenerateObj(filterCRDYaml), "")
+		ctx.Add(internal.GenerateObj(orderDeplYaml), "")
+		ctx.Add(internal.GenerateObj(orderSAYaml), "")
+		return ctx
+	}
+	t.Run("only deployments", func(t *testing.T) {
+		out := &outputMock{}
+		err := newCtx(config.Config{OnlyKinds: []string{"Deployment"}}, out).CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"deployment.yaml"}, out.filenames)
+	})
+	t.Run("skip crds", func(t *testing.T) {
+		out := &outputMock{}
+		err := newCtx(config.Config{SkipKinds: []string{"customresourcedefinition"}}, out).CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"serviceaccount.yaml", "deployment.yaml"}, out.filenames)
+	})
+	t.Run("no filter", func(t *testing.T) {
+		out := &outputMock{}
+		err := newCtx(config.Config{}, out).CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Len(t, out.filenames, 3)
+	})
+}
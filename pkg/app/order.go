@@ -0,0 +1,88 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// kindOrder - priority of k8s kinds in the resulting chart. Resources with kinds not listed here are placed
+// after the listed ones and keep their original input order.
+var kindOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"PriorityClass",
+	"RuntimeClass",
+	"ServiceAccount",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"ConfigMap",
+	"Secret",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"Service",
+	"DaemonSet",
+	"Deployment",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+}
+
+func kindPriority(kind string) int {
+	for i, k := range kindOrder {
+		if k == kind {
+			return i
+		}
+	}
+	return len(kindOrder)
+}
+
+// sortByKind - stable sorts objects and their source filenames by kind priority.
+func sortByKind(objects []*unstructured.Unstructured, fileNames []string) {
+	sort.Stable(byKind{objects: objects, fileNames: fileNames})
+}
+
+type byKind struct {
+	objects   []*unstructured.Unstructured
+	fileNames []string
+}
+
+func (b byKind) Len() int {
+	return len(b.objects)
+}
+
+func (b byKind) Less(i, j int) bool {
+	return kindPriority(b.objects[i].GetKind()) < kindPriority(b.objects[j].GetKind())
+}
+
+func (b byKind) Swap(i, j int) {
+	b.objects[i], b.objects[j] = b.objects[j], b.objects[i]
+	b.fileNames[i], b.fileNames[j] = b.fileNames[j], b.fileNames[i]
+}
+
+// orderedFilenames - prefixes template filenames with numbers in order of their first appearance, e.g.
+// 01-deployment.yaml, so files are listed in the input documents order.
+type orderedFilenames struct {
+	numbers map[string]int
+	width   int
+}
+
+// newOrderedFilenames - returns orderedFilenames for given number of objects. Numbers are zero-padded to at least
+// two digits to be sorted lexicographically.
+func newOrderedFilenames(count int) *orderedFilenames {
+	return &orderedFilenames{numbers: map[string]int{}, width: max(len(strconv.Itoa(count)), 2)}
+}
+
+func (o *orderedFilenames) name(filename string) string {
+	n, ok := o.numbers[filename]
+	if !ok {
+		n = len(o.numbers) + 1
+		o.numbers[filename] = n
+	}
+	return fmt.Sprintf("%0*d-%s", o.width, n, filename)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor/deployment"
+	"github.com/arttor/helmify/pkg/processor/rbac"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	orderDeplYaml = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25.0`
+	orderSAYaml = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: my-app-sa`
+)
+
+type outputMock struct {
+	templates []helmify.Template
+	filenames []string
+}
+
+func (o *outputMock) Create(_ config.Config, templates []helmify.Template, filenames []string) error {
+	o.templates = templates
+	o.filenames = filenames
+	return nil
+}
+
+func Test_sortByKind(t *testing.T) {
+	t.Run("service account sorted before deployment", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart"}, out).
+			WithProcessors(deployment.New(), rbac.ServiceAccount())
+		ctx.Add(internal.GenerateObj(orderDeplYaml), "")
+		ctx.Add(internal.GenerateObj(orderSAYaml), "")
+
+		err := ctx.CreateHelm(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"serviceaccount.yaml", "deployment.yaml"}, out.filenames)
+	})
+	t.Run("unknown kinds keep input order", func(t *testing.T) {
+		a := internal.GenerateObj("apiVersion: example.com/v1\nkind: Foo\nmetadata:\n  name: a")
+		b := internal.GenerateObj("apiVersion: example.com/v1\nkind: Bar\nmetadata:\n  name: b")
+		sa := internal.GenerateObj(orderSAYaml)
+		objects := []*unstructured.Unstructured{a, b, sa}
+		fileNames := []string{"a.yaml", "b.yaml", "sa.yaml"}
+
+		sortByKind(objects, fileNames)
+		assert.Equal(t, []string{"sa.yaml", "a.yaml", "b.yaml"}, fileNames)
+		assert.Equal(t, "ServiceAccount", objects[0].GetKind())
+	})
+}
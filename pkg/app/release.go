@@ -0,0 +1,101 @@
+package app
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// helmLabels - labels injected by Helm chart helpers. Generated chart adds them back with its own labels helper.
+var helmLabels = []string{
+	"app.kubernetes.io/version",
+	"app.kubernetes.io/managed-by",
+	"helm.sh/chart",
+}
+
+// helmSelectorLabels - labels injected by Helm chart selector labels helper.
+var helmSelectorLabels = []string{
+	"app.kubernetes.io/name",
+	"app.kubernetes.io/instance",
+}
+
+// selectorLabelsKinds - kinds with selector and pod labels extended by generated chart selector labels helper.
+// Helm selector labels are removed from nested selectors only for them, other kinds keep selectors as is.
+// Keep in sync with processors including selectorLabels helper, otherwise the labels are duplicated.
+var selectorLabelsKinds = map[string]bool{
+	"Deployment":          true,
+	"DaemonSet":           true,
+	"Service":             true,
+	"PodDisruptionBudget": true,
+	"Rollout":             true,
+	"DeploymentConfig":    true,
+	"PodMonitor":          true,
+}
+
+// helmAnnotations - annotations set by Helm on release resources.
+var helmAnnotations = []string{
+	"meta.helm.sh/release-name",
+	"meta.helm.sh/release-namespace",
+}
+
+// isHelmRelease - returns true if object was rendered from a Helm chart, e.g. taken from 'helm get manifest' output.
+func isHelmRelease(obj *unstructured.Unstructured) bool {
+	labels := obj.GetLabels()
+	if labels["app.kubernetes.io/managed-by"] == "Helm" {
+		return true
+	}
+	if _, ok := labels["helm.sh/chart"]; ok {
+		return true
+	}
+	_, ok := obj.GetAnnotations()["meta.helm.sh/release-name"]
+	return ok
+}
+
+// stripHelmMeta - removes labels and annotations injected by Helm from Helm release object, so they do not clash
+// with the ones added by generated chart helpers. Objects not managed by Helm are left untouched.
+func stripHelmMeta(obj *unstructured.Unstructured) {
+	if !isHelmRelease(obj) {
+		return
+	}
+	strip := helmLabels
+	if selectorLabelsKinds[obj.GetKind()] {
+		strip = append(strip[:len(strip):len(strip)], helmSelectorLabels...)
+	}
+	stripHelmMetaFields(obj.Object, strip)
+
+	// top level labels are always replaced with chart labels helper.
+	labels := obj.GetLabels()
+	for _, l := range helmSelectorLabels {
+		delete(labels, l)
+	}
+	if len(labels) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "labels")
+	}
+}
+
+func stripHelmMetaFields(obj map[string]interface{}, labels []string) {
+	for key, val := range obj {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			switch key {
+			case "labels", "matchLabels", "selector":
+				for _, l := range labels {
+					delete(v, l)
+				}
+			case "annotations":
+				for _, a := range helmAnnotations {
+					delete(v, a)
+				}
+			}
+			if len(v) == 0 && (key == "labels" || key == "annotations") {
+				delete(obj, key)
+				continue
+			}
+			stripHelmMetaFields(v, labels)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					stripHelmMetaFields(m, labels)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const helmReleaseDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-release-web
+  labels:
+    app.kubernetes.io/name: web
+    app.kubernetes.io/instance: my-release
+    app.kubernetes.io/version: 1.0.0
+    app.kubernetes.io/managed-by: Helm
+    helm.sh/chart: web-1.0.0
+    tier: frontend
+  annotations:
+    meta.helm.sh/release-name: my-release
+    meta.helm.sh/release-namespace: default
+spec:
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: web
+      app.kubernetes.io/instance: my-release
+  template:
+    metadata:
+      labels:
+        app.kubernetes.io/name: web
+        app.kubernetes.io/instance: my-release
+        helm.sh/chart: web-1.0.0
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25.0`
+
+func Test_stripHelmMeta(t *testing.T) {
+	t.Run("helm release labels and annotations stripped", func(t *testing.T) {
+		out := &outputMock{}
+		ctx := New(config.Config{ChartName: "chart"}, out)
+		ctx.Add(internal.GenerateObj(helmReleaseDeployment), "")
+		obj := ctx.objects[0]
+
+		assert.Equal(t, map[string]string{"tier": "frontend"}, obj.GetLabels())
+		assert.Empty(t, obj.GetAnnotations())
+		matchLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+		assert.Empty(t, matchLabels)
+		_, exists, _ := unstructured.NestedMap(obj.Object, "spec", "template", "metadata", "labels")
+		assert.False(t, exists)
+	})
+	t.Run("pod disruption budget selector labels stripped", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: policy/v1
+kind: PodDisruptionBudget
+metadata:
+  name: my-release-web
+  labels:
+    app.kubernetes.io/name: web
+    app.kubernetes.io/instance: my-release
+    app.kubernetes.io/managed-by: Helm
+spec:
+  minAvailable: 1
+  selector:
+    matchLabels:
+      app.kubernetes.io/name: web
+      app.kubernetes.io/instance: my-release
+      tier: frontend`)
+		stripHelmMeta(obj)
+
+		assert.Empty(t, obj.GetLabels())
+		matchLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+		assert.Equal(t, map[string]string{"tier": "frontend"}, matchLabels)
+	})
+	t.Run("selectors kept for kinds without chart selector labels", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  labels:
+    app.kubernetes.io/instance: my-release
+    app.kubernetes.io/managed-by: Helm
+spec:
+  selector:
+    matchLabels:
+      app.kubernetes.io/instance: my-release`)
+		stripHelmMeta(obj)
+
+		assert.Empty(t, obj.GetLabels())
+		matchLabels, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector", "matchLabels")
+		assert.Equal(t, map[string]string{"app.kubernetes.io/instance": "my-release"}, matchLabels)
+	})
+	t.Run("objects not managed by helm untouched", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    app.kubernetes.io/name: web
+spec:
+  selector:
+    app.kubernetes.io/name: web`)
+		stripHelmMeta(obj)
+
+		assert.Equal(t, map[string]string{"app.kubernetes.io/name": "web"}, obj.GetLabels())
+		selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector")
+		assert.Equal(t, map[string]string{"app.kubernetes.io/name": "web"}, selector)
+	})
+}
@@ -0,0 +1,42 @@
+package app
+
+// defaultStripKeys - labels and annotations set by k8s clients and controllers which are only noise in a chart.
+var defaultStripKeys = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"kubectl.kubernetes.io/restartedAt",
+	"deployment.kubernetes.io/revision",
+}
+
+// stripMetadata - removes labels and annotations with given keys from object metadata and nested
+// metadata, e.g. pod templates.
+func stripMetadata(obj map[string]interface{}, keys []string) {
+	for key, val := range obj {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if key == "metadata" {
+				stripMetaKeys(v, "labels", keys)
+				stripMetaKeys(v, "annotations", keys)
+			}
+			stripMetadata(v, keys)
+		case []interface{}:
+			for _, item := range v {
+				if m, ok := item.(map[string]interface{}); ok {
+					stripMetadata(m, keys)
+				}
+			}
+		}
+	}
+}
+
+func stripMetaKeys(meta map[string]interface{}, field string, keys []string) {
+	m, ok := meta[field].(map[string]interface{})
+	if !ok {
+		return
+	}
+	for _, k := range keys {
+		delete(m, k)
+	}
+	if len(m) == 0 {
+		delete(meta, field)
+	}
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const exportedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  annotations:
+    kubectl.kubernetes.io/last-applied-configuration: '{"apiVersion":"apps/v1","kind":"Deployment"}'
+    deployment.kubernetes.io/revision: "3"
+    team: payments
+  labels:
+    argocd.argoproj.io/instance: web
+spec:
+  template:
+    metadata:
+      annotations:
+        kubectl.kubernetes.io/restartedAt: "2024-01-01T00:00:00Z"
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25.0`
+
+func Test_stripMetadata(t *testing.T) {
+	t.Run("default keys removed", func(t *testing.T) {
+		ctx := New(config.Config{ChartName: "chart"}, &outputMock{})
+		ctx.Add(internal.GenerateObj(exportedDeployment), "")
+		obj := ctx.objects[0]
+
+		assert.Equal(t, map[string]string{"team": "payments"}, obj.GetAnnotations())
+		assert.Equal(t, map[string]string{"argocd.argoproj.io/instance": "web"}, obj.GetLabels())
+		_, exists, _ := unstructured.NestedMap(obj.Object, "spec", "template", "metadata", "annotations")
+		assert.False(t, exists)
+	})
+	t.Run("configured keys removed", func(t *testing.T) {
+		ctx := New(config.Config{ChartName: "chart", StripMetadata: []string{"argocd.argoproj.io/instance"}}, &outputMock{})
+		ctx.Add(internal.GenerateObj(exportedDeployment), "")
+		obj := ctx.objects[0]
+
+		assert.Empty(t, obj.GetLabels())
+		assert.NotContains(t, obj.GetAnnotations(), "kubectl.kubernetes.io/last-applied-configuration")
+	})
+}
@@ -9,6 +9,17 @@ import (
 // defaultChartName - default name for a helm chart directory.
 const defaultChartName = "chart"
 
+const (
+	// OutputFormatDir - chart is written to the filesystem only.
+	OutputFormatDir = "dir"
+	// OutputFormatBundle - chart is written to the filesystem and printed to stdout as a single yaml bundle.
+	OutputFormatBundle = "bundle"
+	// OutputFormatKustomize - Kustomize base and overlay are written to the filesystem instead of a Helm chart.
+	OutputFormatKustomize = "kustomize"
+	// OutputFormatPatch - existing chart is not changed, JSON6902 patch of values and files changes is printed to stdout.
+	OutputFormatPatch = "patch"
+)
+
 // Config for Helmify application.
 type Config struct {
 	// ChartName name of the Helm chart and its base directory where Chart.yaml is located.
@@ -21,8 +32,29 @@ type Config struct {
 	VeryVerbose bool
 	// crd-dir set true to enable crd folder.
 	Crd bool
-	// ImagePullSecrets flag
+	// ImagePullSecrets adds chart-wide .Values.imagePullSecrets to every pod, merged with pod image pull secrets.
 	ImagePullSecrets bool
+	// GlobalImageRegistry prepends overridable .Values.global.imageRegistry to all container images.
+	GlobalImageRegistry bool
+	// GlobalValues hoists values shared by subcharts, i.e. image registry, imagePullSecrets, storageClass and labels,
+	// to .Values.global. Templates reference them with fallback to per-resource values. Implies GlobalImageRegistry.
+	GlobalValues bool
+	// PrefixClassNames prefixes cluster-wide PriorityClass and RuntimeClass names with chart fullname to avoid collisions.
+	PrefixClassNames bool
+	// ReleasePrefixClusterScoped names cluster-scoped resources, e.g. ClusterRoles and webhook configurations, with
+	// release name prefix instead of chart fullname, so multiple releases can be installed into one cluster even with
+	// fullnameOverride. Names of namespaced resources are not changed.
+	ReleasePrefixClusterScoped bool
+	// StripHashSuffix - remove content hash suffixes added by kustomize configMapGenerator and secretGenerator from
+	// ConfigMap and Secret names, e.g. my-config-7fmb6gk9t4, so chart names are stable.
+	StripHashSuffix bool
+	// RemovePrefixes - prefixes trimmed from resource names in the given order. Common prefix detection is used for
+	// names not matching any of them.
+	RemovePrefixes []string
+	// TrimSuffix - detect common suffix of resource names, e.g. added by kustomize nameSuffix, and trim it.
+	TrimSuffix bool
+	// DecodeSecrets - put decoded text values of Opaque secrets to values.yaml as defaults instead of empty placeholders.
+	DecodeSecrets bool
 	// GenerateDefaults enables the generation of empty values placeholders for common customization options of helm chart
 	// current generated values: tolerances, node selectors, topology constraints
 	GenerateDefaults bool
@@ -34,6 +66,95 @@ type Config struct {
 	Files []string
 	// FilesRecursively read Files recursively
 	FilesRecursively bool
+	// OCIRef - reference of OCI artifact with k8s manifests pulled instead of reading Files or stdin,
+	// e.g. ghcr.io/org/manifests:1.0.0. Registry credentials are taken from docker config.
+	OCIRef string
+	// OCIPlainHTTP - pull OCIRef over plain HTTP, e.g. from local registry.
+	OCIPlainHTTP bool
+	// Strict - fail on lossy conversions (dropped data and fields, resources without processor) instead of logging warnings.
+	Strict bool
+	// Lint enables warnings about common anti-patterns in input manifests, e.g. latest image tags or missing resource limits.
+	Lint bool
+	// GenerateReadme enables generation of chart README.md documenting values.yaml.
+	GenerateReadme bool
+	// HelmTests enables generation of Helm test Pod in templates/tests checking connectivity to chart Services.
+	HelmTests bool
+	// ValidateChart enables rendering of the generated chart with Helm to check it for errors.
+	ValidateChart bool
+	// DefaultsFile - optional path to yaml file with values deep merged over extracted values.yaml defaults.
+	DefaultsFile string
+	// ValuesFromEnv - dot-separated values paths with names of environment variables read at generation time to
+	// set their defaults in values.yaml, e.g. web.nginx.image.tag: CI_TAG. Applied after DefaultsFile.
+	ValuesFromEnv map[string]string
+	// OutputFormat - chart output format: OutputFormatDir, OutputFormatBundle, OutputFormatKustomize or OutputFormatPatch.
+	// Empty means OutputFormatDir.
+	OutputFormat string
+	// StripMetadata - label and annotation keys removed from all objects in addition to the default ones,
+	// e.g. kubectl.kubernetes.io/last-applied-configuration.
+	StripMetadata []string
+	// ConfigMapDataBlock - lift the whole data of every ConfigMap to a single values map <name>.data rendered with
+	// toYaml instead of lifting data keys one by one. ConfigMaps with helmify.io/values annotation are not affected.
+	ConfigMapDataBlock bool
+	// ContainerPortValues - lift container ports to .Values.<name>.<container>.ports.<portName> integer values.
+	// Unnamed ports are lifted by index, e.g. port0.
+	ContainerPortValues bool
+	// NestContainerValues - key values of init and ephemeral containers under initContainers and ephemeralContainers,
+	// e.g. .Values.<name>.initContainers.<container>, so they never collide with main containers.
+	NestContainerValues bool
+	// GroupManagerConfig - lift leaderElection, metrics, webhook and health settings of controller-runtime
+	// ControllerManagerConfig stored in ConfigMaps to top level values, e.g. .Values.leaderElection.
+	GroupManagerConfig bool
+	// NoLabels - do not add chart labels helper include to resources metadata, keep only labels from the source.
+	NoLabels bool
+	// APIVersions - apiVersion pinned per object kind, e.g. Deployment: apps/v1. Used instead of the source apiVersion.
+	APIVersions map[string]string
+	// IndentWidth - indentation width of generated templates and values.yaml. Empty means 2 spaces.
+	IndentWidth int
+	// ChartMetadataFile - optional path to yaml file with Chart.yaml fields added to generated Chart.yaml, e.g.
+	// maintainers and annotations for Artifact Hub. Applied when the chart skeleton is created.
+	ChartMetadataFile string
+	// LicenseHeaderFile - optional path to file with header prepended to generated yaml files as a comment.
+	LicenseHeaderFile string
+	// PostRenderCommands - shell commands every generated file content is piped through before it is written.
+	PostRenderCommands []string
+	// AssumeYes - overwrite existing chart files without interactive confirmation.
+	AssumeYes bool
+	// Force - confirm overwriting existing chart files when stdin is not a terminal, so no warning is logged.
+	Force bool
+	// ValuesAnchors - write repeated structures of values.yaml, e.g. identical container resources, once with yaml
+	// anchor and reference them with aliases.
+	ValuesAnchors bool
+	// SplitValues - in addition to combined values.yaml, write values used by every template file to the file with
+	// the same name in values dir of the chart, e.g. values/deployment.yaml.
+	SplitValues bool
+	// ExtractValues - instead of processing input, scan templates of existing chart ChartDir/ChartName for .Values
+	// references and add stubs for values missing in its values.yaml.
+	ExtractValues bool
+	// VerboseFilenames - prefix generated template filenames with object kind and apiVersion, e.g.
+	// deployment-apps-v1-myapp.yaml. Filenames of input files set with Files are prefixed as well.
+	VerboseFilenames bool
+	// PreserveOrder - keep input documents order instead of sorting objects by kind and prefix generated template
+	// filenames with numbers in this order, e.g. 01-myapp.yaml. Filenames of input files set with Files are prefixed as well.
+	PreserveOrder bool
+	// KeepPSP - keep deprecated PodSecurityPolicy objects in the chart instead of dropping them with a warning.
+	KeepPSP bool
+	// OnlyKinds - if set, only objects of given kinds are added to the chart. Can't be used with SkipKinds.
+	OnlyKinds []string
+	// SkipKinds - objects of given kinds are not added to the chart, e.g. CustomResourceDefinition.
+	SkipKinds []string
+	// CapabilitiesKinds - templates of objects of given kinds are wrapped into .Capabilities.APIVersions.Has check
+	// of their apiVersion, e.g. ServiceMonitor is installed only if Prometheus operator CRDs are present.
+	CapabilitiesKinds []string
+	// FeatureGroups - feature group by object name. Templates of objects of a group are wrapped into
+	// .Values.<group>.enabled toggle, e.g. webhook configuration, its Service and Certificate under webhooks.
+	FeatureGroups map[string]string
+	// FeatureLabel - label key with feature group of objects not listed in FeatureGroups, e.g. app.kubernetes.io/component.
+	FeatureLabel string
+	// CommonLabels - additional labels added to the labels helper and thus to every chart resource.
+	CommonLabels map[string]string
+	// CommonAnnotations - additional annotations added to the annotations helper included into every chart resource.
+	// Annotations of the source object take precedence.
+	CommonAnnotations map[string]string
 }
 
 func (c *Config) Validate() error {
@@ -48,5 +169,19 @@ func (c *Config) Validate() error {
 		}
 		return fmt.Errorf("invalid chart name %s", c.ChartName)
 	}
+	switch c.OutputFormat {
+	case "", OutputFormatDir, OutputFormatBundle, OutputFormatKustomize, OutputFormatPatch:
+	default:
+		return fmt.Errorf("invalid output format %q: expected %s, %s, %s or %s", c.OutputFormat, OutputFormatDir, OutputFormatBundle, OutputFormatKustomize, OutputFormatPatch)
+	}
+	if c.IndentWidth != 0 && (c.IndentWidth < 2 || c.IndentWidth > 8) {
+		return fmt.Errorf("invalid indent width %d: expected value from 2 to 8", c.IndentWidth)
+	}
+	if c.OCIRef != "" && len(c.Files) != 0 {
+		return fmt.Errorf("OCI artifact and files can't be used together")
+	}
+	if len(c.OnlyKinds) != 0 && len(c.SkipKinds) != 0 {
+		return fmt.Errorf("only kinds and skip kinds can't be used together")
+	}
 	return nil
 }
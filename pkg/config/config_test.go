@@ -47,4 +47,23 @@ func TestConfig_Validate(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, "test", c.ChartName)
 	})
+	t.Run("output format", func(t *testing.T) {
+		assert.NoError(t, (&Config{OutputFormat: OutputFormatBundle}).Validate())
+		assert.NoError(t, (&Config{OutputFormat: OutputFormatKustomize}).Validate())
+		assert.Error(t, (&Config{OutputFormat: "zip"}).Validate())
+	})
+	t.Run("indent width", func(t *testing.T) {
+		assert.NoError(t, (&Config{IndentWidth: 4}).Validate())
+		assert.Error(t, (&Config{IndentWidth: 1}).Validate())
+		assert.Error(t, (&Config{IndentWidth: 10}).Validate())
+	})
+	t.Run("kind filters", func(t *testing.T) {
+		assert.NoError(t, (&Config{OnlyKinds: []string{"Deployment"}}).Validate())
+		assert.NoError(t, (&Config{SkipKinds: []string{"CustomResourceDefinition"}}).Validate())
+		assert.Error(t, (&Config{OnlyKinds: []string{"Deployment"}, SkipKinds: []string{"Service"}}).Validate())
+	})
+	t.Run("oci ref", func(t *testing.T) {
+		assert.NoError(t, (&Config{OCIRef: "ghcr.io/org/manifests:1.0.0"}).Validate())
+		assert.Error(t, (&Config{OCIRef: "ghcr.io/org/manifests:1.0.0", Files: []string{"./manifests"}}).Validate())
+	})
 }
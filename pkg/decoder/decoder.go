@@ -17,13 +17,19 @@ const (
 )
 
 // Decode - reads bytes stream of k8s yaml manifests and decodes it to k8s unstructured objects.
+// Gzipped and tar input is unpacked first, see Unpack.
 // Non-blocking function. Sends results into buffered channel. Closes channel on io.EOF.
 func Decode(stop <-chan struct{}, reader io.Reader) <-chan *unstructured.Unstructured {
-	decoder := yamlutil.NewYAMLOrJSONDecoder(reader, yamlDecoderBufferSize)
 	res := make(chan *unstructured.Unstructured, decoderResultChannelBufferSize)
 	go func() {
 		defer close(res)
 		logrus.Debug("Start processing...")
+		unpacked, err := Unpack(reader)
+		if err != nil {
+			logrus.WithError(err).Error("unable to unpack input")
+			return
+		}
+		decoder := yamlutil.NewYAMLOrJSONDecoder(unpacked, yamlDecoderBufferSize)
 		for {
 			select {
 			case <-stop:
@@ -1,10 +1,15 @@
 package decoder
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 const (
@@ -97,3 +102,65 @@ func TestDecodeInvalidObj(t *testing.T) {
 	}
 	assert.Equal(t, 2, i, "decoded 2 valid objects")
 }
+
+func TestDecodeGzip(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(validObjects2))
+	assert.NoError(t, err)
+	assert.NoError(t, gz.Close())
+
+	stop := make(chan struct{})
+	plain := decodeAll(stop, strings.NewReader(validObjects2))
+	assert.Len(t, plain, 2)
+	assert.Equal(t, plain, decodeAll(stop, &gzipped))
+}
+
+func TestDecodeTarGzip(t *testing.T) {
+	var archive bytes.Buffer
+	gz := gzip.NewWriter(&archive)
+	tw := tar.NewWriter(gz)
+	service, namespace, _ := strings.Cut(validObjects2, "---\n")
+	files := []struct{ name, content string }{
+		{name: "manifests/service.yaml", content: service},
+		{name: "manifests/README.md", content: "# not a manifest"},
+		{name: "manifests/namespace.yml", content: namespace},
+	}
+	for _, f := range files {
+		err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0600, Size: int64(len(f.content)), Typeflag: tar.TypeReg})
+		assert.NoError(t, err)
+		_, err = tw.Write([]byte(f.content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gz.Close())
+
+	stop := make(chan struct{})
+	assert.Equal(t, decodeAll(stop, strings.NewReader(validObjects2)), decodeAll(stop, &archive))
+}
+
+func TestUnpackTar(t *testing.T) {
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	for _, content := range []string{"a: 1\n", "b: 2\n"} {
+		err := tw.WriteHeader(&tar.Header{Name: "m.yaml", Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		assert.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+
+	unpacked, err := Unpack(&archive)
+	assert.NoError(t, err)
+	res, err := io.ReadAll(unpacked)
+	assert.NoError(t, err)
+	assert.Equal(t, "a: 1\n\n---\nb: 2\n", string(res), "separator is written between entries only")
+}
+
+func decodeAll(stop chan struct{}, reader io.Reader) []*unstructured.Unstructured {
+	var res []*unstructured.Unstructured
+	for obj := range Decode(stop, reader) {
+		res = append(res, obj)
+	}
+	return res
+}
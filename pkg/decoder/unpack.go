@@ -0,0 +1,85 @@
+package decoder
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// tarMagicOffset - offset of 'ustar' magic in tar header.
+	tarMagicOffset = 257
+	tarHeaderSize  = 512
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	tarMagic  = []byte("ustar")
+)
+
+// Unpack - returns reader with plain manifests for gzipped stream, tar archive or gzipped tar archive.
+// Yaml and json files from tar archive are concatenated as yaml documents. Other input is returned as is.
+func Unpack(reader io.Reader) (io.Reader, error) {
+	buf := bufio.NewReaderSize(reader, tarHeaderSize)
+	magic, err := buf.Peek(len(gzipMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unable to read input", err)
+	}
+	if bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to read gzip input", err)
+		}
+		logrus.Debug("gzip input detected")
+		return Unpack(gz)
+	}
+	header, err := buf.Peek(tarHeaderSize)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: unable to read input", err)
+	}
+	if len(header) < tarMagicOffset+len(tarMagic) || !bytes.Equal(header[tarMagicOffset:tarMagicOffset+len(tarMagic)], tarMagic) {
+		return buf, nil
+	}
+	logrus.Debug("tar input detected")
+	return untar(buf)
+}
+
+func untar(reader io.Reader) (io.Reader, error) {
+	var res bytes.Buffer
+	archive := tar.NewReader(reader)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return &res, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to read tar input", err)
+		}
+		if header.Typeflag != tar.TypeReg || !isManifest(header.Name) {
+			continue
+		}
+		logrus.WithField("file", header.Name).Debug("reading from tar")
+		if res.Len() != 0 {
+			res.WriteString("\n---\n")
+		}
+		if _, err = io.Copy(&res, archive); err != nil {
+			return nil, fmt.Errorf("%w: unable to read %s from tar input", err, header.Name)
+		}
+	}
+}
+
+func isManifest(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package file
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// IgnoreFile - name of file in input directory listing glob patterns of files to skip, similar to .dockerignore.
+const IgnoreFile = ".helmifyignore"
+
+// ignore - glob patterns of files to skip in input directory.
+type ignore []string
+
+// readIgnore - reads ignore patterns from IgnoreFile in given directory. Empty lines and lines starting with # are
+// skipped. Missing file means nothing is ignored.
+func readIgnore(dir string) ignore {
+	f, err := os.Open(filepath.Join(dir, IgnoreFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Warnf("unable to open %s in %q: %v", IgnoreFile, dir, err)
+		}
+		return nil
+	}
+	defer f.Close()
+	var res ignore
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		res = append(res, strings.TrimPrefix(line, "/"))
+	}
+	if err = scanner.Err(); err != nil {
+		logrus.Warnf("unable to read %s in %q: %v", IgnoreFile, dir, err)
+	}
+	return res
+}
+
+// match - reports whether file or directory with given slash separated path relative to the input directory is
+// ignored. Patterns without slash are also matched against the base name in any subdirectory, patterns ending with
+// slash match directories only. The ignore file itself is always ignored.
+func (i ignore) match(rel string, isDir bool) bool {
+	if !isDir && rel == IgnoreFile {
+		return true
+	}
+	for _, pattern := range i {
+		dirOnly := strings.HasSuffix(pattern, "/")
+		if dirOnly {
+			if !isDir {
+				continue
+			}
+			pattern = strings.TrimSuffix(pattern, "/")
+		}
+		if ok, _ := path.Match(pattern, rel); ok {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(rel)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -6,8 +6,16 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
+// manifestExts - extensions of manifest files read from directories. Archives are unpacked by decoder.
+var manifestExts = map[string]bool{
+	".yaml": true, ".yml": true, ".json": true, ".gz": true, ".tgz": true, ".tar": true,
+}
+
+// Walk - calls walkFunc for every file of given paths. Directories are read for manifest files, see manifestExts,
+// hidden files and subdirectories are skipped as well as files matching patterns from IgnoreFile in the directory.
 func Walk(paths []string, recursively bool, walkFunc func(filename string, r io.Reader)) {
 
 	for _, path := range paths {
@@ -30,6 +38,7 @@ func Walk(paths []string, recursively bool, walkFunc func(filename string, r io.
 			}
 			continue
 		}
+		ignored := readIgnore(path)
 		// handle directory non-recursively:
 		if !recursively {
 			dir, err := os.Open(path)
@@ -43,7 +52,7 @@ func Walk(paths []string, recursively bool, walkFunc func(filename string, r io.
 				continue
 			}
 			for _, f := range files {
-				if f.IsDir() {
+				if f.IsDir() || !isManifest(f.Name()) || ignored.match(f.Name(), false) {
 					continue
 				}
 				file, err := os.Open(filepath.Join(path, f.Name()))
@@ -61,11 +70,22 @@ func Walk(paths []string, recursively bool, walkFunc func(filename string, r io.
 			continue
 		}
 		// handle directory recursively:
-		err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		root := path
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
-			if d.IsDir() {
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			if rel != "." && (strings.HasPrefix(d.Name(), ".") || ignored.match(filepath.ToSlash(rel), d.IsDir())) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !isManifest(d.Name()) {
 				return nil
 			}
 			file, err := os.Open(path)
@@ -85,3 +105,18 @@ func Walk(paths []string, recursively bool, walkFunc func(filename string, r io.
 		}
 	}
 }
+
+// IsArchive - reports whether file with given name is an archive holding many manifests, so its name must not be
+// used as template filename.
+func IsArchive(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".gz", ".tgz", ".tar":
+		return true
+	}
+	return false
+}
+
+// isManifest - reports whether file with given name is visible manifest file.
+func isManifest(name string) bool {
+	return !strings.HasPrefix(name, ".") && manifestExts[strings.ToLower(filepath.Ext(name))]
+}
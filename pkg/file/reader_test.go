@@ -0,0 +1,81 @@
+package file
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalk_ignore(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		IgnoreFile:                   "# local overrides\nsecret-local.yaml\nkustomization.yaml\ntmp/\n",
+		"deployment.yaml":            "kind: Deployment",
+		"secret-local.yaml":          "kind: Secret",
+		"kustomization.yaml":         "resources: []",
+		"base/service.yaml":          "kind: Service",
+		"base/kustomization.yaml":    "resources: []",
+		"tmp/configmap.yaml":         "kind: ConfigMap",
+		"overlays/tmp.yaml/pvc.yaml": "kind: PersistentVolumeClaim",
+	} {
+		file := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	}
+	walk := func(recursively bool) []string {
+		var res []string
+		Walk([]string{dir}, recursively, func(filename string, r io.Reader) {
+			content, err := io.ReadAll(r)
+			assert.NoError(t, err)
+			res = append(res, string(content))
+		})
+		return res
+	}
+
+	t.Run("non-recursive", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"kind: Deployment"}, walk(false))
+	})
+	t.Run("recursive", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"kind: Deployment", "kind: Service", "kind: PersistentVolumeClaim"}, walk(true))
+	})
+	t.Run("single file not filtered", func(t *testing.T) {
+		var res []string
+		Walk([]string{filepath.Join(dir, "secret-local.yaml")}, false, func(filename string, r io.Reader) {
+			res = append(res, filename)
+		})
+		assert.Equal(t, []string{"secret-local.yaml"}, res)
+	})
+}
+
+func TestWalk_recursively(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"app/deployment.yaml":          "kind: Deployment",
+		"app/web/service.yml":          "kind: Service",
+		"app/web/config/settings.json": `{"kind": "ConfigMap"}`,
+		"app/db/statefulset.YAML":      "kind: StatefulSet",
+		"app/db/backup.tar":            "",
+		"app/README.md":                "# manifests",
+		"app/.hidden.yaml":             "kind: Secret",
+		".git/config.yaml":             "kind: Secret",
+		"app/.cache/pod.yaml":          "kind: Pod",
+	} {
+		file := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0700))
+		assert.NoError(t, os.WriteFile(file, []byte(content), 0600))
+	}
+	var res []string
+	Walk([]string{dir}, true, func(filename string, r io.Reader) {
+		res = append(res, filename)
+	})
+	assert.ElementsMatch(t, []string{"deployment.yaml", "service.yml", "settings.json", "statefulset.YAML", "backup.tar"}, res)
+
+	res = nil
+	Walk([]string{filepath.Join(dir, "app")}, false, func(filename string, r io.Reader) {
+		res = append(res, filename)
+	})
+	assert.Equal(t, []string{"deployment.yaml"}, res, "non-recursive")
+}
@@ -0,0 +1,17 @@
+package format
+
+import "strings"
+
+const (
+	leftDelimPlaceholder  = "\x00helmify-left-delim\x00"
+	rightDelimPlaceholder = "\x00helmify-right-delim\x00"
+)
+
+// EscapeTemplates escapes go template delimiters so Helm renders them as is.
+// Useful for resources with own templating, e.g. Prometheus alert annotations: {{ $value }} -> {{ "{{" }} $value {{ "}}" }}.
+func EscapeTemplates(in string) string {
+	in = strings.ReplaceAll(in, "{{", leftDelimPlaceholder)
+	in = strings.ReplaceAll(in, "}}", rightDelimPlaceholder)
+	in = strings.ReplaceAll(in, leftDelimPlaceholder, `{{ "{{" }}`)
+	return strings.ReplaceAll(in, rightDelimPlaceholder, `{{ "}}" }}`)
+}
@@ -0,0 +1,29 @@
+package format
+
+import "testing"
+
+func TestEscapeTemplates(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "template escaped",
+			in:   `summary: '{{ $labels.instance }} down'`,
+			want: `summary: '{{ "{{" }} $labels.instance {{ "}}" }} down'`,
+		},
+		{
+			name: "no templates",
+			in:   `expr: up == 0`,
+			want: `expr: up == 0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EscapeTemplates(tt.in); got != tt.want {
+				t.Errorf("EscapeTemplates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package format
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// quotedTemplateRe - matches single-line yaml single-quoted scalar starting with helm template, e.g.
+// '{{ include "app.fullname" . }}-web'.
+var quotedTemplateRe = regexp.MustCompile(`'(\{\{(?:[^'\n]|'')*)'`)
+
+// UnquoteTemplates removes yaml single quotes wrapping helm template values: '{{ .Values.a }}' -> {{ .Values.a }}.
+// Unlike removing all single quotes, it keeps quotes yaml requires for keys and plain strings with special characters.
+// Scalars are found by yaml parser, so multi-line scalars folded by yaml marshaller are unquoted as well, while quotes
+// inside other scalars, e.g. '{{x}}' in json string, are kept. Input which is not yaml is unquoted line by line.
+func UnquoteTemplates(in string) string {
+	starts, err := quotedTemplateStarts(in)
+	if err != nil {
+		return quotedTemplateRe.ReplaceAllStringFunc(in, func(quoted string) string {
+			return strings.ReplaceAll(quoted[1:len(quoted)-1], "''", "'")
+		})
+	}
+	var res strings.Builder
+	prev := 0
+	for _, start := range starts {
+		end := start + 1
+		// closing quote is the first one not escaped by doubling.
+		for end < len(in) && (in[end] != '\'' || (end+1 < len(in) && in[end+1] == '\'')) {
+			if in[end] == '\'' {
+				end++
+			}
+			end++
+		}
+		if end >= len(in) {
+			break
+		}
+		res.WriteString(in[prev:start])
+		res.WriteString(strings.ReplaceAll(in[start+1:end], "''", "'"))
+		prev = end + 1
+	}
+	res.WriteString(in[prev:])
+	return res.String()
+}
+
+// quotedTemplateStarts - returns sorted byte offsets of opening quotes of single-quoted scalars starting with helm
+// template in given yaml.
+func quotedTemplateStarts(in string) ([]int, error) {
+	lineOffsets := []int{0}
+	for i, c := range in {
+		if c == '\n' {
+			lineOffsets = append(lineOffsets, i+1)
+		}
+	}
+	var starts []int
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode && node.Style == yaml.SingleQuotedStyle && strings.HasPrefix(node.Value, "{{") {
+			line, _, _ := strings.Cut(in[lineOffsets[node.Line-1]:], "\n")
+			// column is counted in characters.
+			if start := lineOffsets[node.Line-1] + len(string([]rune(line)[:node.Column-1])); in[start] == '\'' {
+				starts = append(starts, start)
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(in))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		walk(&doc)
+	}
+	sort.Ints(starts)
+	return starts, nil
+}
@@ -0,0 +1,64 @@
+package format
+
+import "testing"
+
+func TestUnquoteTemplates(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "templated value unquoted",
+			in:   `key: '{{ .Values.a.key | quote }}'`,
+			want: `key: {{ .Values.a.key | quote }}`,
+		},
+		{
+			name: "quoted key preserved",
+			in:   `'@key': '{{ .Values.a.key | quote }}'`,
+			want: `'@key': {{ .Values.a.key | quote }}`,
+		},
+		{
+			name: "templated name unquoted",
+			in:   `name: '{{ include "app.fullname" . }}-web'`,
+			want: `name: {{ include "app.fullname" . }}-web`,
+		},
+		{
+			name: "templated key and value unquoted",
+			in:   `'{{ .Values.a.key }}': '{{ .Values.a.value }}'`,
+			want: `{{ .Values.a.key }}: {{ .Values.a.value }}`,
+		},
+		{
+			name: "quoted plain value preserved",
+			in:   `key: '@reboot echo hi'`,
+			want: `key: '@reboot echo hi'`,
+		},
+		{
+			name: "apostrophe preserved",
+			in:   "host: '*.example.com'\nmessage: it's {{ .Values.a }}",
+			want: "host: '*.example.com'\nmessage: it's {{ .Values.a }}",
+		},
+		{
+			name: "folded templated value unquoted",
+			in:   "image: '{{ .Values.web.image.repository }}:{{ .Values.web.image.tag | default\n  .Chart.AppVersion }}'\nname: web",
+			want: "image: {{ .Values.web.image.repository }}:{{ .Values.web.image.tag | default\n  .Chart.AppVersion }}\nname: web",
+		},
+		{
+			name: "quoted braces inside other scalars preserved",
+			in:   "json: '{\"a\": ''{{x}}''}'\nfolded: \"say '{{x}}' to\n  all\"\nplain: it is '{{x}}'",
+			want: "json: '{\"a\": ''{{x}}''}'\nfolded: \"say '{{x}}' to\n  all\"\nplain: it is '{{x}}'",
+		},
+		{
+			name: "escaped quote in templated value",
+			in:   "- '{{ .Values.a | default ''x'' }}'\n- '{{ .Values.b }}'",
+			want: "- {{ .Values.a | default 'x' }}\n- {{ .Values.b }}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UnquoteTemplates(tt.in); got != tt.want {
+				t.Errorf("UnquoteTemplates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
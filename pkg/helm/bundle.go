@@ -0,0 +1,112 @@
+package helm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const bundleSeparator = "---\n"
+
+// bundleManifest - first document of the bundle listing all chart files in the order they follow.
+type bundleManifest struct {
+	Files []string `json:"files"`
+}
+
+// bundleFile - bundle document with a single chart file.
+type bundleFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// WriteBundle - writes all files from chartDir into writer as a single yaml stream. The stream starts with
+// a manifest of the file names relative to chartDir followed by a document per file.
+// Use ReadBundle to reconstitute the chart.
+func WriteBundle(writer io.Writer, chartDir string) error {
+	var files []string
+	err := filepath.WalkDir(chartDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(chartDir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%w: unable to list chart files in %s", err, chartDir)
+	}
+	sort.Strings(files)
+
+	docs := []interface{}{bundleManifest{Files: files}}
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(chartDir, filepath.FromSlash(file)))
+		if err != nil {
+			return fmt.Errorf("%w: unable to read %s", err, file)
+		}
+		docs = append(docs, bundleFile{Path: file, Content: string(content)})
+	}
+	for i, doc := range docs {
+		res, err := yaml.Marshal(doc)
+		if err != nil {
+			return fmt.Errorf("%w: unable to marshal chart bundle", err)
+		}
+		if i != 0 {
+			res = append([]byte(bundleSeparator), res...)
+		}
+		if _, err = writer.Write(res); err != nil {
+			return fmt.Errorf("%w: unable to write chart bundle", err)
+		}
+	}
+	return nil
+}
+
+// ReadBundle - reads chart bundle written by WriteBundle and recreates chart files in chartDir.
+func ReadBundle(reader io.Reader, chartDir string) error {
+	docs := yamlutil.NewYAMLReader(bufio.NewReader(reader))
+	doc, err := docs.Read()
+	if err != nil {
+		return fmt.Errorf("%w: unable to read chart bundle manifest", err)
+	}
+	manifest := bundleManifest{}
+	if err = yaml.Unmarshal(doc, &manifest); err != nil {
+		return fmt.Errorf("%w: unable to parse chart bundle manifest", err)
+	}
+	for _, name := range manifest.Files {
+		doc, err = docs.Read()
+		if errors.Is(err, io.EOF) {
+			return fmt.Errorf("chart bundle is missing file %s", name)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: unable to read chart bundle", err)
+		}
+		file := bundleFile{}
+		if err = yaml.Unmarshal(doc, &file); err != nil {
+			return fmt.Errorf("%w: unable to parse chart bundle file %s", err, name)
+		}
+		if file.Path != name {
+			return fmt.Errorf("chart bundle file %s does not match manifest entry %s", file.Path, name)
+		}
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			return fmt.Errorf("chart bundle file path %s is outside of chart dir", file.Path)
+		}
+		path := filepath.Join(chartDir, filepath.FromSlash(file.Path))
+		if err = os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			return fmt.Errorf("%w: unable to create dir for %s", err, file.Path)
+		}
+		if err = os.WriteFile(path, []byte(file.Content), 0600); err != nil {
+			return fmt.Errorf("%w: unable to write %s", err, file.Path)
+		}
+	}
+	return nil
+}
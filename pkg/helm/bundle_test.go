@@ -0,0 +1,66 @@
+package helm
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const multiDocTemplate = validTemplate + `
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: trailing-spaces   
+data:
+  rules.yaml: |
+    a: b
+    ---
+    c: d
+`
+
+func TestBundle(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		chartDir := createTestChart(t, multiDocTemplate)
+		buf := bytes.Buffer{}
+		err := WriteBundle(&buf, chartDir)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "files:\n- .helmignore\n- Chart.yaml\n")
+
+		restoredDir := t.TempDir()
+		err = ReadBundle(&buf, restoredDir)
+		assert.NoError(t, err)
+		assert.Equal(t, readChartFiles(t, chartDir), readChartFiles(t, restoredDir))
+	})
+	t.Run("missing file", func(t *testing.T) {
+		err := ReadBundle(bytes.NewBufferString("files:\n- Chart.yaml\n"), t.TempDir())
+		assert.Error(t, err)
+	})
+	t.Run("path outside chart dir", func(t *testing.T) {
+		err := ReadBundle(bytes.NewBufferString("files:\n- ../evil.yaml\n---\npath: ../evil.yaml\ncontent: x\n"), t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func readChartFiles(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	res := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		res[rel] = string(content)
+		return nil
+	})
+	assert.NoError(t, err)
+	return res
+}
@@ -1,25 +1,35 @@
 package helm
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/arttor/helmify/pkg/cluster"
+	"github.com/arttor/helmify/pkg/config"
 	"github.com/arttor/helmify/pkg/helmify"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
 
 	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"sigs.k8s.io/yaml"
 )
 
+// splitValuesDir - chart subdirectory for per-resource values files, see config.SplitValues.
+const splitValuesDir = "values"
+
 // NewOutput creates interface to dump processed input to filesystem in Helm chart format.
-func NewOutput() helmify.Output {
-	return &output{}
+// Given transformers are applied to every generated file before it is written, see Transformer.
+func NewOutput(transformers ...Transformer) helmify.Output {
+	return &output{transformers: transformers}
 }
 
-type output struct{}
+type output struct {
+	transformers transformers
+}
 
 // Create a helm chart in the current directory:
 // chartName/
@@ -27,47 +37,122 @@ type output struct{}
 //	├── .helmignore   	# Contains patterns to ignore when packaging Helm charts.
 //	├── Chart.yaml    	# Information about your chart
 //	├── values.yaml   	# The default values for your templates
+//	├── README.md     	# Optional values documentation
 //	└── templates/    	# The template files
 //	    └── _helpers.tp   # Helm default template partials
 //
-// Overwrites existing values.yaml and templates in templates dir on every run.
-func (o output) Create(chartDir, chartName string, crd bool, certManagerAsSubchart bool, certManagerVersion string, templates []helmify.Template, filenames []string) error {
-	err := initChartDir(chartDir, chartName, crd, certManagerAsSubchart, certManagerVersion)
-	if err != nil {
-		return err
+// Overwrites existing values.yaml and templates in templates dir on every run after confirmation, see confirmOverwrite.
+// With config.OutputFormatPatch the chart is not changed, the patch of changes is printed instead, see createPatch.
+func (o output) Create(conf config.Config, templates []helmify.Template, filenames []string) error {
+	if conf.OutputFormat == config.OutputFormatPatch {
+		return o.createPatch(conf, templates, filenames, os.Stdout)
 	}
+	var err error
 	// group templates into files
 	files := map[string][]helmify.Template{}
+	// keep files in order of first appearance to write them deterministically
+	var fileOrder []string
+	sources := valueSources{}
+	// keys of values used by every template file, copied before merge modifies nested template values.
+	fileShapes := map[string][]map[string]interface{}{}
 	values := helmify.Values{}
 	values[cluster.DomainKey] = cluster.DefaultDomain
 	for i, template := range templates {
-		file := files[filenames[i]]
+		file, exists := files[filenames[i]]
+		if !exists {
+			fileOrder = append(fileOrder, filenames[i])
+		}
 		file = append(file, template)
 		files[filenames[i]] = file
+		if conf.SplitValues {
+			fileShapes[filenames[i]] = append(fileShapes[filenames[i]], copyMaps(template.Values()))
+		}
 		err = values.Merge(template.Values())
 		if err != nil {
 			return err
 		}
+		sources.add(template.Values(), filenames[i])
+	}
+	if conf.GlobalValues {
+		err = values.Merge(helmify.Values{"global": map[string]interface{}{"labels": map[string]interface{}{}}})
+		if err != nil {
+			return err
+		}
+	}
+	if conf.DefaultsFile != "" {
+		err = overrideDefaults(values, conf.DefaultsFile)
+		if err != nil {
+			return err
+		}
+	}
+	if len(conf.ValuesFromEnv) != 0 {
+		err = setFromEnv(values, conf.ValuesFromEnv)
+		if err != nil {
+			return err
+		}
+	}
+	cDir := filepath.Join(conf.ChartDir, conf.ChartName)
+	targets := []string{filepath.Join(cDir, "values.yaml")}
+	if conf.GenerateReadme {
+		targets = append(targets, filepath.Join(cDir, "README.md"))
+	}
+	for _, filename := range fileOrder {
+		targets = append(targets, filepath.Join(cDir, templatesSubdir(filename, conf.Crd), filename))
+		if conf.SplitValues {
+			targets = append(targets, filepath.Join(cDir, splitValuesDir, filename))
+		}
+	}
+	err = confirmOverwrite(conf, existingFiles(targets), os.Stdin, os.Stderr)
+	if err != nil {
+		return err
 	}
-	cDir := filepath.Join(chartDir, chartName)
-	for filename, tpls := range files {
-		err = overwriteTemplateFile(filename, cDir, crd, tpls)
+	transform, err := o.transformers.withConfig(conf)
+	if err != nil {
+		return err
+	}
+	err = initChartDir(conf)
+	if err != nil {
+		return err
+	}
+	for _, filename := range fileOrder {
+		err = overwriteTemplateFile(filename, cDir, conf.Crd, files[filename], transform)
 		if err != nil {
 			return err
 		}
 	}
-	err = overwriteValuesFile(cDir, values, certManagerAsSubchart)
+	err = overwriteValuesFile(cDir, values, conf, transform)
 	if err != nil {
 		return err
 	}
+	if conf.SplitValues {
+		for _, filename := range fileOrder {
+			err = overwriteSplitValuesFile(filename, cDir, values, fileShapes[filename], conf, transform)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if conf.GenerateReadme {
+		err = overwriteReadmeFile(cDir, conf.ChartName, values, sources, transform)
+		if err != nil {
+			return err
+		}
+	}
+	if conf.ValidateChart {
+		err = validateChart(cDir)
+		if err != nil {
+			return err
+		}
+	}
+	if conf.OutputFormat == config.OutputFormatBundle {
+		return WriteBundle(os.Stdout, cDir)
+	}
 	return nil
 }
 
-func overwriteTemplateFile(filename, chartDir string, crd bool, templates []helmify.Template) error {
-	// pull in crd-dir setting and siphon crds into folder
-	var subdir string
-	if strings.Contains(filename, "crd") && crd {
-		subdir = "crds"
+func overwriteTemplateFile(filename, chartDir string, crd bool, templates []helmify.Template, transform transformers) error {
+	subdir := templatesSubdir(filename, crd)
+	if subdir == "crds" {
 		// create "crds" if not exists
 		if _, err := os.Stat(filepath.Join(chartDir, "crds")); os.IsNotExist(err) {
 			err = os.MkdirAll(filepath.Join(chartDir, "crds"), 0750)
@@ -75,34 +160,80 @@ func overwriteTemplateFile(filename, chartDir string, crd bool, templates []helm
 				return fmt.Errorf("%w: unable create crds dir", err)
 			}
 		}
-	} else {
-		subdir = "templates"
 	}
 	file := filepath.Join(chartDir, subdir, filename)
-	f, err := os.OpenFile(file, os.O_APPEND|os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	if err != nil {
-		return fmt.Errorf("%w: unable to open %s", err, file)
+	// templates may be placed in subdirs, e.g. tests
+	if err := os.MkdirAll(filepath.Dir(file), 0750); err != nil {
+		return fmt.Errorf("%w: unable create dir for %s", err, file)
 	}
-	defer f.Close()
+	var buf bytes.Buffer
 	for i, t := range templates {
 		logrus.WithField("file", file).Debug("writing a template into")
-		err = t.Write(f)
+		err := t.Write(&buf)
 		if err != nil {
 			return fmt.Errorf("%w: unable to write into %s", err, file)
 		}
 		if i != len(templates)-1 {
-			_, err = f.Write([]byte("\n---\n"))
-			if err != nil {
-				return fmt.Errorf("%w: unable to write into %s", err, file)
-			}
+			buf.WriteString("\n---\n")
 		}
 	}
+	if err := transform.writeFile(chartDir, file, buf.Bytes()); err != nil {
+		return err
+	}
 	logrus.WithField("file", file).Info("overwritten")
 	return nil
 }
 
-func overwriteValuesFile(chartDir string, values helmify.Values, certManagerAsSubchart bool) error {
-	if certManagerAsSubchart {
+// templatesSubdir - returns chart subdirectory for template file: crds are siphoned into 'crds' if crd-dir is set.
+func templatesSubdir(filename string, crd bool) string {
+	if strings.Contains(filename, "crd") && crd {
+		return "crds"
+	}
+	return "templates"
+}
+
+// overrideDefaults - deep merges values from defaults file over extracted values.
+func overrideDefaults(values helmify.Values, file string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("%w: unable to read defaults file %s", err, file)
+	}
+	defaults := helmify.Values{}
+	err = yaml.Unmarshal(content, &defaults)
+	if err != nil {
+		return fmt.Errorf("%w: unable to parse defaults file %s", err, file)
+	}
+	return values.Override(defaults)
+}
+
+// setFromEnv - sets values with given dot-separated paths to values of given environment variables, e.g.
+// web.nginx.image.tag=CI_TAG. Value is parsed as yaml if generated default is not a string. Values with unset
+// environment variables keep generated defaults.
+func setFromEnv(values helmify.Values, fromEnv map[string]string) error {
+	for key, env := range fromEnv {
+		envValue, ok := os.LookupEnv(env)
+		if !ok {
+			logrus.WithFields(logrus.Fields{"value": key, "env": env}).Warn("environment variable is not set, keeping generated default")
+			continue
+		}
+		path := strings.Split(key, ".")
+		var value interface{} = envValue
+		if def, found, _ := unstructured.NestedFieldNoCopy(values, path...); found {
+			if _, isString := def.(string); !isString {
+				if err := yaml.Unmarshal([]byte(envValue), &value); err != nil {
+					return fmt.Errorf("%w: unable to parse environment variable %s value for %s", err, env, key)
+				}
+			}
+		}
+		if err := unstructured.SetNestedField(values, value, path...); err != nil {
+			return fmt.Errorf("%w: unable to set value %s from environment variable %s", err, key, env)
+		}
+	}
+	return nil
+}
+
+func overwriteValuesFile(chartDir string, values helmify.Values, conf config.Config, transform transformers) error {
+	if conf.CertManagerAsSubchart {
 		_, err := values.Add(true, "certmanager", "installCRDs")
 		if err != nil {
 			return fmt.Errorf("%w: unable to add cert-manager.installCRDs", err)
@@ -113,16 +244,97 @@ func overwriteValuesFile(chartDir string, values helmify.Values, certManagerAsSu
 			return fmt.Errorf("%w: unable to add cert-manager.enabled", err)
 		}
 	}
-	res, err := yaml.Marshal(values)
+	res, err := marshalValues(values, conf)
 	if err != nil {
 		return fmt.Errorf("%w: unable to write marshal values.yaml", err)
 	}
 
 	file := filepath.Join(chartDir, "values.yaml")
-	err = os.WriteFile(file, res, 0600)
+	err = transform.writeFile(chartDir, file, res)
+	if err != nil {
+		return err
+	}
+	logrus.WithField("file", file).Info("overwritten")
+	return nil
+}
+
+// overwriteSplitValuesFile - writes values with keys of given shapes to the file with the same name as template file
+// in splitValuesDir, e.g. values/deployment.yaml. Defaults are taken from combined values, so they match
+// values.yaml. Nothing is written for templates without values.
+func overwriteSplitValuesFile(filename, chartDir string, values helmify.Values, shapes []map[string]interface{}, conf config.Config, transform transformers) error {
+	fileValues := map[string]interface{}{}
+	for _, shape := range shapes {
+		pickValues(values, shape, fileValues)
+	}
+	if len(fileValues) == 0 {
+		return nil
+	}
+	res, err := marshalValues(fileValues, conf)
 	if err != nil {
-		return fmt.Errorf("%w: unable to write values.yaml", err)
+		return fmt.Errorf("%w: unable to marshal values of %s", err, filename)
+	}
+	dir := filepath.Join(chartDir, splitValuesDir)
+	if err = os.MkdirAll(dir, 0750); err != nil {
+		return fmt.Errorf("%w: unable create %s dir", err, splitValuesDir)
+	}
+	file := filepath.Join(dir, filename)
+	if err = transform.writeFile(chartDir, file, res); err != nil {
+		return err
 	}
 	logrus.WithField("file", file).Info("overwritten")
 	return nil
 }
+
+// pickValues - copies values from given source with keys present in shape into given map.
+func pickValues(from, shape, into map[string]interface{}) {
+	for key, s := range shape {
+		val, ok := from[key]
+		if !ok {
+			continue
+		}
+		shapeMap, isShapeMap := s.(map[string]interface{})
+		valMap, isValMap := val.(map[string]interface{})
+		if isShapeMap && isValMap && len(shapeMap) != 0 {
+			nested, ok := into[key].(map[string]interface{})
+			if !ok {
+				nested = map[string]interface{}{}
+				into[key] = nested
+			}
+			pickValues(valMap, shapeMap, nested)
+			continue
+		}
+		into[key] = val
+	}
+}
+
+// copyMaps - returns copy of given values with nested maps copied. Other values are not copied.
+func copyMaps(values map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(values))
+	for key, val := range values {
+		switch v := val.(type) {
+		case map[string]interface{}:
+			res[key] = copyMaps(v)
+		case helmify.Values:
+			res[key] = copyMaps(v)
+		default:
+			res[key] = val
+		}
+	}
+	return res
+}
+
+// marshalValues - marshals values to yaml with multi-line strings as block scalars, anchors if enabled and comments
+// listing options of enum-like values.
+func marshalValues(values map[string]interface{}, conf config.Config) ([]byte, error) {
+	var res []byte
+	var err error
+	if conf.ValuesAnchors {
+		res, err = yamlformat.MarshalAnchored(values)
+	} else {
+		res, err = yaml.Marshal(values)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return enumComments(res)
+}
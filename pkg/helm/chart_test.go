@@ -0,0 +1,126 @@
+package helm
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_overwriteValuesFile(t *testing.T) {
+	t.Run("multi-line strings as block scalars", func(t *testing.T) {
+		dir := t.TempDir()
+		values := helmify.Values{
+			"config": map[string]interface{}{
+				"appYaml": "server:\n  port: 8080\n",
+				"list":    []interface{}{"first\nsecond"},
+				"name":    "app ",
+			},
+		}
+		err := overwriteValuesFile(dir, values, config.Config{}, nil)
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, `config:
+  appYaml: |
+    server:
+      port: 8080
+  list:
+  - |-
+    first
+    second
+  name: 'app '
+`, string(content))
+	})
+	t.Run("multi-line strings not representable as block scalars kept", func(t *testing.T) {
+		for _, anchors := range []bool{false, true} {
+			dir := t.TempDir()
+			values := helmify.Values{
+				"config": map[string]interface{}{
+					"trailing": "server:  \n  port: 8080\t\n",
+					"crlf":     "server:\r\n  port: 8080\r\n",
+				},
+			}
+			err := overwriteValuesFile(dir, values, config.Config{ValuesAnchors: anchors}, nil)
+			assert.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+			assert.NoError(t, err)
+			parsed := map[string]interface{}{}
+			assert.NoError(t, yaml.Unmarshal(content, &parsed))
+			assert.Equal(t, map[string]interface{}(values), parsed)
+		}
+	})
+	t.Run("repeated structures as anchors", func(t *testing.T) {
+		dir := t.TempDir()
+		values := helmify.Values{
+			"web": map[string]interface{}{
+				"app":     map[string]interface{}{"resources": map[string]interface{}{"limits": map[string]interface{}{"cpu": "500m"}}},
+				"sidecar": map[string]interface{}{"resources": map[string]interface{}{"limits": map[string]interface{}{"cpu": "500m"}}},
+			},
+		}
+		err := overwriteValuesFile(dir, values, config.Config{ValuesAnchors: true}, nil)
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "values.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, `web:
+  app: &app
+    resources:
+      limits:
+        cpu: 500m
+  sidecar: *app
+`, string(content))
+	})
+}
+
+// valuesTemplate - template with given values.
+type valuesTemplate struct {
+	values helmify.Values
+}
+
+func (valuesTemplate) Filename() string { return "" }
+
+func (t valuesTemplate) Values() helmify.Values { return t.values }
+
+func (valuesTemplate) Write(writer io.Writer) error {
+	_, err := writer.Write([]byte(validTemplate))
+	return err
+}
+
+func TestCreate_splitValues(t *testing.T) {
+	conf := config.Config{ChartDir: t.TempDir(), ChartName: "chart", SplitValues: true}
+	templates := []helmify.Template{
+		valuesTemplate{values: helmify.Values{"web": map[string]interface{}{"replicas": int64(1), "image": map[string]interface{}{"tag": "1.0"}}}},
+		valuesTemplate{values: helmify.Values{"web": map[string]interface{}{"ports": []interface{}{int64(80)}}}},
+		valuesTemplate{values: helmify.Values{"webConfig": map[string]interface{}{"logLevel": "info"}}},
+		valuesTemplate{},
+	}
+	filenames := []string{"deployment.yaml", "service.yaml", "config.yaml", "empty.yaml"}
+
+	err := NewOutput().Create(conf, templates, filenames)
+	assert.NoError(t, err)
+
+	chartDir := filepath.Join(conf.ChartDir, "chart")
+	content, err := os.ReadFile(filepath.Join(chartDir, "values.yaml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "webConfig:", "combined values")
+	assert.Contains(t, string(content), "replicas: 1", "combined values")
+	for file, expected := range map[string]string{
+		"deployment.yaml": "web:\n  image:\n    tag: \"1.0\"\n  replicas: 1\n",
+		"service.yaml":    "web:\n  ports:\n  - 80\n",
+		"config.yaml":     "webConfig:\n  logLevel: info\n",
+	} {
+		content, err = os.ReadFile(filepath.Join(chartDir, "values", file))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content), file)
+	}
+	assert.NoFileExists(t, filepath.Join(chartDir, "values", "empty.yaml"), "no values")
+	assert.NoError(t, validateChart(chartDir))
+}
@@ -0,0 +1,59 @@
+package helm
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrOverwriteNotConfirmed - returned when existing chart files would be overwritten without confirmation.
+var ErrOverwriteNotConfirmed = errors.New("overwrite not confirmed")
+
+// isTerminal - reports whether stdin is an interactive terminal.
+var isTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// confirmOverwrite - asks for confirmation to overwrite given existing files. The prompt is read from in and
+// skipped with conf.AssumeYes or conf.Force. If stdin is not a terminal, e.g. manifests are piped or in CI, the prompt
+// is not possible and files are overwritten as before with a warning, conf.Force suppresses it.
+func confirmOverwrite(conf config.Config, files []string, in io.Reader, out io.Writer) error {
+	if len(files) == 0 || conf.Force || conf.AssumeYes {
+		return nil
+	}
+	if !isTerminal() {
+		logrus.WithField("files", strings.Join(files, ", ")).Warn("overwriting existing chart files without confirmation as stdin is not a terminal, use -force to confirm")
+		return nil
+	}
+	_, err := fmt.Fprintf(out, "Following files will be overwritten:\n  %s\nOverwrite? [y/N]: ", strings.Join(files, "\n  "))
+	if err != nil {
+		return fmt.Errorf("%w: unable to print overwrite prompt", err)
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unable to read overwrite confirmation", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	}
+	return ErrOverwriteNotConfirmed
+}
+
+// existingFiles - returns given files which already exist.
+func existingFiles(files []string) []string {
+	var res []string
+	for _, file := range files {
+		if _, err := os.Stat(file); err == nil {
+			res = append(res, file)
+		}
+	}
+	return res
+}
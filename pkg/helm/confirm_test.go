@@ -0,0 +1,97 @@
+package helm
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type testTemplate struct{}
+
+func (testTemplate) Filename() string { return "config.yaml" }
+
+func (testTemplate) Values() helmify.Values { return helmify.Values{"key": "value"} }
+
+func (testTemplate) Write(writer io.Writer) error {
+	_, err := writer.Write([]byte(validTemplate))
+	return err
+}
+
+func withTerminal(t *testing.T, terminal bool) {
+	t.Helper()
+	orig := isTerminal
+	isTerminal = func() bool { return terminal }
+	t.Cleanup(func() { isTerminal = orig })
+}
+
+func Test_confirmOverwrite(t *testing.T) {
+	files := []string{"chart/values.yaml"}
+	t.Run("nothing to overwrite", func(t *testing.T) {
+		withTerminal(t, false)
+		assert.NoError(t, confirmOverwrite(config.Config{}, nil, strings.NewReader(""), io.Discard))
+	})
+	t.Run("not a terminal overwrites without prompt", func(t *testing.T) {
+		withTerminal(t, false)
+		out := bytes.Buffer{}
+		assert.NoError(t, confirmOverwrite(config.Config{}, files, strings.NewReader("n\n"), &out))
+		assert.Empty(t, out.String(), "no prompt without terminal")
+		assert.NoError(t, confirmOverwrite(config.Config{Force: true}, files, strings.NewReader(""), io.Discard))
+		assert.NoError(t, confirmOverwrite(config.Config{AssumeYes: true}, files, strings.NewReader(""), io.Discard))
+	})
+	t.Run("terminal prompt", func(t *testing.T) {
+		withTerminal(t, true)
+		out := bytes.Buffer{}
+		assert.NoError(t, confirmOverwrite(config.Config{}, files, strings.NewReader("y\n"), &out))
+		assert.Contains(t, out.String(), "chart/values.yaml")
+		err := confirmOverwrite(config.Config{}, files, strings.NewReader("n\n"), io.Discard)
+		assert.True(t, errors.Is(err, ErrOverwriteNotConfirmed))
+		err = confirmOverwrite(config.Config{}, files, strings.NewReader(""), io.Discard)
+		assert.True(t, errors.Is(err, ErrOverwriteNotConfirmed))
+	})
+	t.Run("terminal prompt skipped", func(t *testing.T) {
+		withTerminal(t, true)
+		out := bytes.Buffer{}
+		assert.NoError(t, confirmOverwrite(config.Config{AssumeYes: true}, files, strings.NewReader(""), &out))
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestCreate_overwriteNotTerminal(t *testing.T) {
+	withTerminal(t, false)
+	hook := logtest.NewGlobal()
+	t.Cleanup(hook.Reset)
+	conf := config.Config{ChartDir: t.TempDir(), ChartName: "chart"}
+	templates := []helmify.Template{testTemplate{}}
+	filenames := []string{"config.yaml"}
+
+	assert.NoError(t, NewOutput().Create(conf, templates, filenames), "new chart is created without confirmation")
+	assert.Empty(t, warnings(hook))
+	assert.NoError(t, NewOutput().Create(conf, templates, filenames), "piped regeneration keeps working")
+	if warns := warnings(hook); assert.Len(t, warns, 1) {
+		assert.Contains(t, warns[0].Data["files"], filepath.Join(conf.ChartDir, "chart", "templates", "config.yaml"))
+	}
+
+	hook.Reset()
+	conf.Force = true
+	assert.NoError(t, NewOutput().Create(conf, templates, filenames))
+	assert.Empty(t, warnings(hook), "-force confirms overwrite")
+}
+
+func warnings(hook *logtest.Hook) []*logrus.Entry {
+	var res []*logrus.Entry
+	for _, e := range hook.AllEntries() {
+		if e.Level == logrus.WarnLevel {
+			res = append(res, e)
+		}
+	}
+	return res
+}
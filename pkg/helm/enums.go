@@ -0,0 +1,70 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// valueEnum - valid options of well-known value written to values.yaml as a comment.
+type valueEnum struct {
+	key string
+	// sibling - key required in the same mapping, e.g. ports for Service type, to tell apart values with common names.
+	sibling string
+	options []string
+}
+
+// valueEnums - enum-like values lifted by processors. ExternalName Service type is not listed as it requires
+// externalName field not lifted to values.
+var valueEnums = []valueEnum{
+	{key: "type", sibling: "ports", options: []string{"ClusterIP", "NodePort", "LoadBalancer"}},
+	{key: "externalTrafficPolicy", options: []string{"Cluster", "Local"}},
+	{key: "sessionAffinity", options: []string{"None", "ClientIP"}},
+	{key: "imagePullPolicy", options: []string{"Always", "IfNotPresent", "Never"}},
+	{key: "dnsPolicy", options: []string{"ClusterFirst", "ClusterFirstWithHostNet", "Default", "None"}},
+	{key: "updateMode", options: []string{"Off", "Initial", "Recreate", "Auto"}},
+}
+
+// enumComments - appends comments listing valid options to lines of well-known enum-like values in given yaml,
+// e.g. 'type: ClusterIP # ClusterIP, NodePort, LoadBalancer'. Formatting of the yaml is kept as is.
+func enumComments(content []byte) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("%w: unable to parse values", err)
+	}
+	comments := map[int]string{}
+	var walk func(node *yamlv3.Node)
+	walk = func(node *yamlv3.Node) {
+		if node.Kind == yamlv3.MappingNode {
+			keys := map[string]bool{}
+			for i := 0; i < len(node.Content); i += 2 {
+				keys[node.Content[i].Value] = true
+			}
+			for i := 0; i < len(node.Content); i += 2 {
+				key, value := node.Content[i], node.Content[i+1]
+				if value.Kind != yamlv3.ScalarNode || value.Line != key.Line {
+					continue
+				}
+				for _, enum := range valueEnums {
+					if enum.key == key.Value && (enum.sibling == "" || keys[enum.sibling]) {
+						comments[value.Line] = strings.Join(enum.options, ", ")
+					}
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(&doc)
+	if len(comments) == 0 {
+		return content, nil
+	}
+	lines := bytes.Split(content, []byte("\n"))
+	for line, comment := range comments {
+		lines[line-1] = append(lines[line-1], []byte(" # "+comment)...)
+	}
+	return bytes.Join(lines, []byte("\n")), nil
+}
@@ -0,0 +1,43 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_enumComments(t *testing.T) {
+	t.Run("service type and pull policy", func(t *testing.T) {
+		values, err := yaml.Marshal(map[string]interface{}{
+			"webService": map[string]interface{}{
+				"type":  "ClusterIP",
+				"ports": []interface{}{map[string]interface{}{"port": 80}},
+			},
+			"web": map[string]interface{}{
+				"nginx":        map[string]interface{}{"imagePullPolicy": "IfNotPresent"},
+				"nodeSelector": map[string]interface{}{"type": "user-node"},
+			},
+		})
+		assert.NoError(t, err)
+
+		res, err := enumComments(values)
+		assert.NoError(t, err)
+		assert.Equal(t, `web:
+  nginx:
+    imagePullPolicy: IfNotPresent # Always, IfNotPresent, Never
+  nodeSelector:
+    type: user-node
+webService:
+  ports:
+  - port: 80
+  type: ClusterIP # ClusterIP, NodePort, LoadBalancer
+`, string(res))
+	})
+	t.Run("no enums", func(t *testing.T) {
+		values := []byte("config:\n  key: value\n")
+		res, err := enumComments(values)
+		assert.NoError(t, err)
+		assert.Equal(t, values, res)
+	})
+}
@@ -0,0 +1,293 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/sirupsen/logrus"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// valuesFieldRe - matches values reference by field chain, e.g. 'toYaml .Values.foo.bar'.
+	valuesFieldRe = regexp.MustCompile(`(toYaml\s+\$?)?\.Values((?:\.[A-Za-z_][A-Za-z0-9_]*)+)(\s*\|\s*toYaml)?`)
+	// valuesIndexRe - matches values reference by index function, e.g. 'index .Values "foo" "2bar"'.
+	valuesIndexRe = regexp.MustCompile(`(toYaml\s+\(\s*)?index\s+\$?\.Values((?:\s+"[^"]*")+)\s*\)?(\s*\|\s*toYaml)?`)
+	// quotedRe - matches quoted index function argument.
+	quotedRe = regexp.MustCompile(`"([^"]*)"`)
+)
+
+// valuesRef - path of value referenced in a template.
+type valuesRef struct {
+	path []string
+	// object - value is rendered with toYaml, so its stub is an empty map.
+	object bool
+}
+
+// ExtractValues - scans templates of existing chart ChartDir/ChartName for .Values references and adds a stub to
+// its values.yaml for every referenced value missing there. Existing values.yaml is edited in place: its content,
+// comments and key order are kept as is and stubs are inserted at the end of their parent mappings. Values not
+// referenced in templates are reported with a warning. Useful to repair values.yaml drifted from templates.
+func ExtractValues(conf config.Config) error {
+	cDir := filepath.Join(conf.ChartDir, conf.ChartName)
+	refs, err := templateValuesRefs(filepath.Join(cDir, "templates"))
+	if err != nil {
+		return err
+	}
+	values := helmify.Values{}
+	valuesFile := filepath.Join(cDir, "values.yaml")
+	content, err := os.ReadFile(valuesFile)
+	switch {
+	case err == nil:
+		if err = yaml.Unmarshal(content, &values); err != nil {
+			return fmt.Errorf("%w: unable to parse %s", err, valuesFile)
+		}
+	case os.IsNotExist(err):
+		for _, ref := range refs {
+			addStub(values, ref)
+		}
+		transform, err := transformers(nil).withConfig(conf)
+		if err != nil {
+			return err
+		}
+		return overwriteValuesFile(cDir, values, conf, transform)
+	default:
+		return fmt.Errorf("%w: unable to read %s", err, valuesFile)
+	}
+	for _, path := range flattenValues(values, "") {
+		if !referenced(refs, strings.Split(path.key, ".")) {
+			logrus.WithField("value", path.key).Warn("value is not referenced in chart templates")
+		}
+	}
+	indent := conf.IndentWidth
+	if indent == 0 {
+		indent = yamlformat.DefaultIndent
+	}
+	res, err := addStubLines(content, refs, indent)
+	if err != nil {
+		return fmt.Errorf("%w: unable to add stubs to %s", err, valuesFile)
+	}
+	if bytes.Equal(res, content) {
+		return nil
+	}
+
+	err = confirmOverwrite(conf, existingFiles([]string{valuesFile}), os.Stdin, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(valuesFile, res, 0600); err != nil {
+		return fmt.Errorf("%w: unable to write %s", err, valuesFile)
+	}
+	logrus.WithField("file", valuesFile).Info("overwritten")
+	return nil
+}
+
+// templateValuesRefs - returns values referenced in template files of given dir and its subdirs.
+func templateValuesRefs(dir string) ([]valuesRef, error) {
+	var refs []valuesRef
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".tpl", ".txt":
+		default:
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, parseValuesRefs(string(content))...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read chart templates in %s", err, dir)
+	}
+	return refs, nil
+}
+
+// parseValuesRefs - returns values referenced by given template.
+func parseValuesRefs(template string) []valuesRef {
+	var refs []valuesRef
+	for _, match := range valuesFieldRe.FindAllStringSubmatch(template, -1) {
+		refs = append(refs, valuesRef{path: strings.Split(match[2][1:], "."), object: match[1] != "" || match[3] != ""})
+	}
+	for _, match := range valuesIndexRe.FindAllStringSubmatch(template, -1) {
+		var path []string
+		for _, arg := range quotedRe.FindAllStringSubmatch(match[2], -1) {
+			path = append(path, arg[1])
+		}
+		refs = append(refs, valuesRef{path: path, object: match[1] != "" || match[3] != ""})
+	}
+	return refs
+}
+
+// referenced - reports whether value with given path or its parent or child is referenced.
+func referenced(refs []valuesRef, path []string) bool {
+	for _, ref := range refs {
+		n := min(len(ref.path), len(path))
+		if strings.Join(ref.path[:n], ".") == strings.Join(path[:n], ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// addStub - adds empty value for given reference if it is missing in values. Existing values are not changed.
+func addStub(values map[string]interface{}, ref valuesRef) {
+	for i, name := range ref.path {
+		val, exists := values[name]
+		if i == len(ref.path)-1 {
+			if !exists {
+				var stub interface{} = ""
+				if ref.object {
+					stub = map[string]interface{}{}
+				}
+				values[name] = stub
+			}
+			return
+		}
+		if !exists || val == "" || val == nil {
+			val = map[string]interface{}{}
+			values[name] = val
+		}
+		nested, ok := val.(map[string]interface{})
+		if !ok {
+			logrus.WithField("value", strings.Join(ref.path[:i+1], ".")).Warn("value is referenced in templates as object")
+			return
+		}
+		values = nested
+	}
+}
+
+// addStubLines - inserts stubs of references missing in values yaml content as text lines, so the rest of content
+// is kept byte for byte. Positions are taken from yaml node tree, which is parsed again after every insertion.
+// Indent is the indentation width of nested stub mappings.
+func addStubLines(content []byte, refs []valuesRef, indent int) ([]byte, error) {
+	text := string(content)
+	newline := strings.HasSuffix(text, "\n")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if text == "" {
+		lines = nil
+	}
+	for _, ref := range refs {
+		var doc yamlv3.Node
+		if err := yamlv3.Unmarshal([]byte(strings.Join(lines, "\n")), &doc); err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 {
+			// no values, only comments.
+			lines = append(lines, stubLines(ref, ref.path, 0, indent)...)
+			newline = true
+			continue
+		}
+		root := doc.Content[0]
+		if root.Kind != yamlv3.MappingNode || root.Style&yamlv3.FlowStyle != 0 {
+			return nil, fmt.Errorf("values root is not a block mapping")
+		}
+		lines = insertStub(lines, root, ref, indent)
+	}
+	res := strings.Join(lines, "\n")
+	if newline {
+		res += "\n"
+	}
+	return []byte(res), nil
+}
+
+// insertStub - returns lines with stub for given reference inserted at the end of its deepest existing parent
+// mapping. Empty parent values, e.g. "" or {}, are replaced with stub mapping. Existing values are not changed.
+func insertStub(lines []string, root *yamlv3.Node, ref valuesRef, indent int) []string {
+	m := root
+	// end - index of the line following parent mapping, i.e. of the next key of an outer mapping.
+	end := len(lines)
+	for i, name := range ref.path {
+		var key, val *yamlv3.Node
+		for j := 0; j < len(m.Content); j += 2 {
+			if m.Content[j].Value != name {
+				continue
+			}
+			key, val = m.Content[j], m.Content[j+1]
+			if j+2 < len(m.Content) {
+				end = m.Content[j+2].Line - 1
+			}
+			break
+		}
+		column := m.Content[0].Column - 1
+		if key == nil {
+			at := end
+			// comments and empty lines before the next key belong to it.
+			for at > 0 && (strings.TrimSpace(lines[at-1]) == "" || isOuterComment(lines[at-1], column)) {
+				at--
+			}
+			return insertLines(lines, at, stubLines(ref, ref.path[i:], column, indent))
+		}
+		if i == len(ref.path)-1 {
+			return lines
+		}
+		switch {
+		case val.Kind == yamlv3.MappingNode && len(val.Content) != 0 && val.Style&yamlv3.FlowStyle == 0:
+			m = val
+		case isEmptyNode(val) && val.Line == key.Line:
+			line := []rune(lines[key.Line-1])
+			head := strings.TrimRight(string(line[:val.Column-1]), " ")
+			if val.LineComment != "" {
+				head += " " + val.LineComment
+			}
+			lines[key.Line-1] = head
+			return insertLines(lines, key.Line, stubLines(ref, ref.path[i+1:], key.Column-1+indent, indent))
+		default:
+			logrus.WithField("value", strings.Join(ref.path[:i+1], ".")).Warn("value is referenced in templates as object")
+			return lines
+		}
+	}
+	return lines
+}
+
+// stubLines - returns stub lines for given reference nested by given path and indented by given column.
+func stubLines(ref valuesRef, path []string, column, indent int) []string {
+	stub := map[string]interface{}{}
+	addStub(stub, valuesRef{path: path, object: ref.object})
+	res, _ := yaml.Marshal(stub)
+	if indent != yamlformat.DefaultIndent {
+		res = yamlformat.Reindent(res, indent)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(res), "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.Repeat(" ", column) + lines[i]
+	}
+	return lines
+}
+
+// isEmptyNode - reports whether node is an empty value which can be replaced with a mapping: null, "" or {}.
+func isEmptyNode(node *yamlv3.Node) bool {
+	switch node.Kind {
+	case yamlv3.ScalarNode:
+		return node.Tag == "!!null" || (node.Tag == "!!str" && node.Value == "")
+	case yamlv3.MappingNode:
+		return len(node.Content) == 0
+	}
+	return false
+}
+
+// isOuterComment - reports whether line is a comment indented not deeper than given column.
+func isOuterComment(line string, column int) bool {
+	trimmed := strings.TrimLeft(line, " ")
+	return strings.HasPrefix(trimmed, "#") && len(line)-len(trimmed) <= column
+}
+
+func insertLines(lines []string, at int, inserted []string) []string {
+	res := make([]string, 0, len(lines)+len(inserted))
+	res = append(res, lines[:at]...)
+	res = append(res, inserted...)
+	return append(res, lines[at:]...)
+}
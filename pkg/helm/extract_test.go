@@ -0,0 +1,118 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const extractTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "chart.fullname" . }}
+spec:
+  replicas: {{ .Values.foo.bar }}
+  template:
+    spec:
+      containers:
+      - image: {{ .Values.web.image.repository }}:{{ .Values.web.image.tag | default .Chart.AppVersion }}
+        resources: {{- toYaml .Values.web.resources | nindent 10 }}
+        env:
+        - name: WORKERS
+          value: {{ (index .Values "web" "2workers") | quote }}`
+
+func TestExtractValues(t *testing.T) {
+	t.Run("stubs for referenced values", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "chart", "templates"), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart", "templates", "deployment.yaml"), []byte(extractTemplate), 0600))
+
+		err := ExtractValues(config.Config{ChartDir: dir, ChartName: "chart"})
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "chart", "values.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, `foo:
+  bar: ""
+web:
+  2workers: ""
+  image:
+    repository: ""
+    tag: ""
+  resources: {}
+`, string(content))
+	})
+	t.Run("existing values kept", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "chart", "templates"), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart", "templates", "deployment.yaml"), []byte(extractTemplate), 0600))
+		existing := "unused: true\nweb:\n  image:\n    tag: 1.25.0\n  resources:\n    limits:\n      cpu: 100m\n"
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart", "values.yaml"), []byte(existing), 0600))
+
+		err := ExtractValues(config.Config{ChartDir: dir, ChartName: "chart", Force: true})
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "chart", "values.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, `unused: true
+web:
+  image:
+    tag: 1.25.0
+    repository: ""
+  resources:
+    limits:
+      cpu: 100m
+  2workers: ""
+foo:
+  bar: ""
+`, string(content))
+	})
+	t.Run("comments and key order kept", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, os.MkdirAll(filepath.Join(dir, "chart", "templates"), 0700))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart", "templates", "deployment.yaml"), []byte(extractTemplate), 0600))
+		existing := `# Default values for chart.
+web:
+  # Container image.
+  image:
+    tag: 1.25.0 # pinned
+
+  resources: {} # set by operator
+
+# Shared settings.
+foo: "" # replaced by stub
+zoo:
+  - a
+`
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "chart", "values.yaml"), []byte(existing), 0600))
+
+		err := ExtractValues(config.Config{ChartDir: dir, ChartName: "chart", Force: true})
+		assert.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(dir, "chart", "values.yaml"))
+		assert.NoError(t, err)
+		assert.Equal(t, `# Default values for chart.
+web:
+  # Container image.
+  image:
+    tag: 1.25.0 # pinned
+    repository: ""
+
+  resources: {} # set by operator
+  2workers: ""
+
+# Shared settings.
+foo: # replaced by stub
+  bar: ""
+zoo:
+  - a
+`, string(content))
+	})
+	t.Run("missing templates dir", func(t *testing.T) {
+		err := ExtractValues(config.Config{ChartDir: t.TempDir(), ChartName: "chart"})
+		assert.Error(t, err)
+	})
+}
@@ -8,7 +8,11 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/arttor/helmify/pkg/config"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
 	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chart"
+	"sigs.k8s.io/yaml"
 )
 
 const helmIgnore = `# Patterns to ignore when building packages.
@@ -88,6 +92,14 @@ app.kubernetes.io/name: {{ include "<CHARTNAME>.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
 
+{{/*
+Pod image pull secrets merged with chart-wide imagePullSecrets value
+Expects dict with "root" context and optional "secrets" list of the pod
+*/}}
+{{- define "<CHARTNAME>.imagePullSecrets" -}}
+{{- concat (.secrets | default list) (.root.Values.imagePullSecrets | default list) | uniq | toJson }}
+{{- end }}
+
 {{/*
 Create the name of the service account to use
 */}}
@@ -132,20 +144,51 @@ dependencies:
     version: %q
 `
 
+// globalLabels - labels from .Values.global.labels added to the labels helper with global values enabled.
+const globalLabels = `
+{{- with .Values.global.labels }}
+{{ toYaml . }}
+{{- end }}`
+
+// imagePullSecretsHelper, globalImagePullSecretsHelper - imagePullSecrets helper body without and with
+// .Values.global.imagePullSecrets merged into pod secrets.
+const (
+	imagePullSecretsHelper       = "{{- concat (.secrets | default list) (.root.Values.imagePullSecrets | default list) | uniq | toJson }}"
+	globalImagePullSecretsHelper = "{{- concat (.secrets | default list) (.root.Values.global.imagePullSecrets | default list) (.root.Values.imagePullSecrets | default list) | uniq | toJson }}"
+)
+
+// commonAnnotationsHelper - helper with user-defined annotations added to every chart resource.
+const commonAnnotationsHelper = `
+{{/*
+Common annotations
+*/}}
+{{- define "<CHARTNAME>.annotations" -}}
+%s
+{{- end }}
+`
+
+// commonAnnotationsAnchor - helper placed before common annotations helper.
+const commonAnnotationsAnchor = `
+{{/*
+Selector labels`
+
+// commonLabelsAnchor - line in the labels helper after which user-defined common labels are placed.
+const commonLabelsAnchor = "app.kubernetes.io/managed-by: {{ .Release.Service }}"
+
 var chartName = regexp.MustCompile("^[a-zA-Z0-9._-]+$")
 
 const maxChartNameLength = 250
 
 // initChartDir - creates Helm chart structure in chartName directory if not presented.
-func initChartDir(chartDir, chartName string, crd bool, certManagerAsSubchart bool, certManagerVersion string) error {
-	if err := validateChartName(chartName); err != nil {
+func initChartDir(conf config.Config) error {
+	if err := validateChartName(conf.ChartName); err != nil {
 		return err
 	}
 
-	cDir := filepath.Join(chartDir, chartName)
+	cDir := filepath.Join(conf.ChartDir, conf.ChartName)
 	_, err := os.Stat(filepath.Join(cDir, "Chart.yaml"))
 	if os.IsNotExist(err) {
-		return createCommonFiles(chartDir, chartName, crd, certManagerAsSubchart, certManagerVersion)
+		return createCommonFiles(conf)
 	}
 	logrus.Info("Skip creating Chart skeleton: Chart.yaml already exists.")
 	return err
@@ -161,13 +204,22 @@ func validateChartName(name string) error {
 	return nil
 }
 
-func createCommonFiles(chartDir, chartName string, crd bool, certManagerAsSubchart bool, certManagerVersion string) error {
-	cDir := filepath.Join(chartDir, chartName)
-	err := os.MkdirAll(filepath.Join(cDir, "templates"), 0750)
+func createCommonFiles(conf config.Config) error {
+	// content is generated before any file is written, so no partial chart skeleton is left on error.
+	chartFile, err := chartYAML(conf)
+	if err != nil {
+		return err
+	}
+	helpers, err := helpersYAML(conf.ChartName, conf.CommonLabels, conf.CommonAnnotations, conf.GlobalValues)
+	if err != nil {
+		return err
+	}
+	cDir := filepath.Join(conf.ChartDir, conf.ChartName)
+	err = os.MkdirAll(filepath.Join(cDir, "templates"), 0750)
 	if err != nil {
 		return fmt.Errorf("%w: unable create chart/templates dir", err)
 	}
-	if crd {
+	if conf.Crd {
 		err = os.MkdirAll(filepath.Join(cDir, "crds"), 0750)
 		if err != nil {
 			return fmt.Errorf("%w: unable create crds dir", err)
@@ -183,20 +235,85 @@ func createCommonFiles(chartDir, chartName string, crd bool, certManagerAsSubcha
 			logrus.WithField("file", file).Info("created")
 		}
 	}
-	createFile(chartYAML(chartName, certManagerAsSubchart, certManagerVersion), cDir, "Chart.yaml")
+	createFile(chartFile, cDir, "Chart.yaml")
 	createFile([]byte(helmIgnore), cDir, ".helmignore")
-	createFile(helpersYAML(chartName), cDir, "templates", "_helpers.tpl")
+	createFile(helpers, cDir, "templates", "_helpers.tpl")
 	return err
 }
 
-func chartYAML(appName string, certManagerAsSubchart bool, certManagerVersion string) []byte {
-	chartFile := defaultChartfile
+func chartYAML(conf config.Config) ([]byte, error) {
+	chartFile := fmt.Sprintf(defaultChartfile, conf.ChartName)
+	if conf.CertManagerAsSubchart {
+		chartFile += fmt.Sprintf(certManagerDependencies, conf.CertManagerVersion)
+	}
+	if conf.ChartMetadataFile == "" {
+		return []byte(chartFile), nil
+	}
+	extra, err := chartMetadata(conf.ChartMetadataFile, conf.CertManagerAsSubchart)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(chartFile + extra), nil
+}
+
+// generatedChartFields - Chart.yaml fields set by helmify, they can't be set in chart metadata file.
+var generatedChartFields = []string{"apiVersion", "name", "description", "type", "version", "appVersion"}
+
+// chartMetadata - returns Chart.yaml fields from given file, e.g. maintainers and annotations for Artifact Hub,
+// as yaml to be appended to generated Chart.yaml. Fields are validated against Chart.yaml schema.
+func chartMetadata(file string, certManagerAsSubchart bool) (string, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to read chart metadata file %s", err, file)
+	}
+	if err = yaml.UnmarshalStrict(content, &chart.Metadata{}); err != nil {
+		return "", fmt.Errorf("%w: invalid chart metadata file %s", err, file)
+	}
+	fields := map[string]interface{}{}
+	if err = yaml.Unmarshal(content, &fields); err != nil {
+		return "", fmt.Errorf("%w: unable to parse chart metadata file %s", err, file)
+	}
+	generated := generatedChartFields
 	if certManagerAsSubchart {
-		chartFile += fmt.Sprintf(certManagerDependencies, certManagerVersion)
+		generated = append(generated, "dependencies")
+	}
+	for _, field := range generated {
+		if _, ok := fields[field]; ok {
+			return "", fmt.Errorf("chart metadata file %s: field %s is generated by helmify", file, field)
+		}
+	}
+	if len(fields) == 0 {
+		return "", nil
+	}
+	res, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to marshal chart metadata", err)
 	}
-	return []byte(fmt.Sprintf(chartFile, appName))
+	return string(res), nil
 }
 
-func helpersYAML(chartName string) []byte {
-	return []byte(strings.ReplaceAll(defaultHelpers, "<CHARTNAME>", chartName))
+func helpersYAML(chartName string, commonLabels, commonAnnotations map[string]string, globalValues bool) ([]byte, error) {
+	helpers := defaultHelpers
+	labels := ""
+	if len(commonLabels) != 0 {
+		commonLabelsYaml, err := yamlformat.Marshal(commonLabels, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to marshal common labels", err)
+		}
+		labels = "\n" + commonLabelsYaml
+	}
+	if globalValues {
+		labels += globalLabels
+		helpers = strings.Replace(helpers, imagePullSecretsHelper, globalImagePullSecretsHelper, 1)
+	}
+	helpers = strings.Replace(helpers, commonLabelsAnchor, commonLabelsAnchor+labels, 1)
+	if len(commonAnnotations) != 0 {
+		annotations, err := yamlformat.Marshal(commonAnnotations, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to marshal common annotations", err)
+		}
+		helper := fmt.Sprintf(commonAnnotationsHelper, strings.TrimRight(annotations, "\n"))
+		helpers = strings.Replace(helpers, commonAnnotationsAnchor, helper+commonAnnotationsAnchor, 1)
+	}
+	return []byte(strings.ReplaceAll(helpers, "<CHARTNAME>", chartName)), nil
 }
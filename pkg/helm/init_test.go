@@ -0,0 +1,72 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"helm.sh/helm/v3/pkg/chart"
+	"sigs.k8s.io/yaml"
+)
+
+const chartMetadataFile = `maintainers:
+- name: Jane Doe
+  email: jane@example.com
+  url: https://example.com
+home: https://example.com/my-app
+annotations:
+  artifacthub.io/changes: |
+    - kind: added
+      description: Initial release
+`
+
+func Test_chartYAML(t *testing.T) {
+	t.Run("chart metadata", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "metadata.yaml")
+		assert.NoError(t, os.WriteFile(file, []byte(chartMetadataFile), 0600))
+
+		content, err := chartYAML(config.Config{ChartName: "my-app", ChartMetadataFile: file})
+		assert.NoError(t, err)
+		res := chart.Metadata{}
+		assert.NoError(t, yaml.UnmarshalStrict(content, &res))
+		assert.Equal(t, "my-app", res.Name)
+		assert.Equal(t, "0.1.0", res.Version)
+		assert.Equal(t, []*chart.Maintainer{{Name: "Jane Doe", Email: "jane@example.com", URL: "https://example.com"}}, res.Maintainers)
+		assert.Equal(t, "https://example.com/my-app", res.Home)
+		assert.Equal(t, "- kind: added\n  description: Initial release\n", res.Annotations["artifacthub.io/changes"])
+		assert.NoError(t, res.Validate())
+	})
+	t.Run("generated field", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "metadata.yaml")
+		assert.NoError(t, os.WriteFile(file, []byte("version: 1.0.0"), 0600))
+
+		_, err := chartYAML(config.Config{ChartName: "my-app", ChartMetadataFile: file})
+		assert.ErrorContains(t, err, "field version is generated by helmify")
+	})
+	t.Run("unknown field", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "metadata.yaml")
+		assert.NoError(t, os.WriteFile(file, []byte("maintainer: jane"), 0600))
+
+		_, err := chartYAML(config.Config{ChartName: "my-app", ChartMetadataFile: file})
+		assert.Error(t, err)
+	})
+}
+
+func Test_createCommonFiles(t *testing.T) {
+	t.Run("nothing written on error", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := config.Config{ChartDir: dir, ChartName: "chart", ChartMetadataFile: filepath.Join(dir, "missing.yaml")}
+		assert.Error(t, createCommonFiles(conf))
+		_, err := os.Stat(filepath.Join(dir, "chart"))
+		assert.True(t, os.IsNotExist(err))
+	})
+	t.Run("created", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.NoError(t, createCommonFiles(config.Config{ChartDir: dir, ChartName: "chart"}))
+		for _, file := range []string{"Chart.yaml", ".helmignore", filepath.Join("templates", "_helpers.tpl")} {
+			assert.FileExists(t, filepath.Join(dir, "chart", file))
+		}
+	})
+}
@@ -0,0 +1,187 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"sigs.k8s.io/yaml"
+)
+
+// patchOp - JSON6902 patch operation.
+type patchOp struct {
+	Op    string
+	Path  string
+	Value interface{}
+}
+
+// MarshalJSON - marshals operation with value for every operation except remove, even if the value is empty.
+func (p patchOp) MarshalJSON() ([]byte, error) {
+	res := map[string]interface{}{"op": p.Op, "path": p.Path}
+	if p.Op != "remove" {
+		res["value"] = p.Value
+	}
+	return json.Marshal(res)
+}
+
+// createPatch - generates the chart into a copy of existing chart ChartDir/ChartName and writes JSON6902 patch
+// describing the changes into writer instead of overwriting the chart. The patch is applied to the document
+// {"values": <values.yaml>, "files": {"<path in chart>": "<content>"}}: values changes are described key by key,
+// changed files with their whole content. Files are never removed by helmify, so the patch removes values only.
+func (o output) createPatch(conf config.Config, templates []helmify.Template, filenames []string, writer io.Writer) error {
+	tmp, err := os.MkdirTemp("", "helmify-patch")
+	if err != nil {
+		return fmt.Errorf("%w: unable to create temp dir for patch", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	cDir := filepath.Join(conf.ChartDir, conf.ChartName)
+	genDir := filepath.Join(tmp, conf.ChartName)
+	if _, err = os.Stat(cDir); err == nil {
+		// existing chart is copied, so files which are not overwritten on generation produce no patch.
+		var buf bytes.Buffer
+		if err = WriteBundle(&buf, cDir); err != nil {
+			return err
+		}
+		if err = ReadBundle(&buf, genDir); err != nil {
+			return err
+		}
+	}
+	genConf := conf
+	genConf.ChartDir = tmp
+	genConf.OutputFormat = config.OutputFormatDir
+	genConf.Force = true
+	if err = o.Create(genConf, templates, filenames); err != nil {
+		return err
+	}
+
+	ops, err := chartPatch(cDir, genDir)
+	if err != nil {
+		return err
+	}
+	res, err := yaml.Marshal(ops)
+	if err != nil {
+		return fmt.Errorf("%w: unable to marshal chart patch", err)
+	}
+	if _, err = writer.Write(res); err != nil {
+		return fmt.Errorf("%w: unable to write chart patch", err)
+	}
+	return nil
+}
+
+// chartPatch - returns JSON6902 patch operations turning chart in dir from into chart in dir to, see createPatch.
+func chartPatch(from, to string) ([]patchOp, error) {
+	fromFiles, err := chartFiles(from)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := chartFiles(to)
+	if err != nil {
+		return nil, err
+	}
+	fromValues, toValues := map[string]interface{}{}, map[string]interface{}{}
+	if err = yaml.Unmarshal([]byte(fromFiles["values.yaml"]), &fromValues); err != nil {
+		return nil, fmt.Errorf("%w: unable to parse values.yaml of %s", err, from)
+	}
+	if err = yaml.Unmarshal([]byte(toFiles["values.yaml"]), &toValues); err != nil {
+		return nil, fmt.Errorf("%w: unable to parse generated values.yaml", err)
+	}
+	ops := diffValues("/values", fromValues, toValues)
+
+	names := make([]string, 0, len(toFiles))
+	for name := range toFiles {
+		if name != "values.yaml" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		content, exists := fromFiles[name]
+		switch {
+		case !exists:
+			ops = append(ops, patchOp{Op: "add", Path: "/files/" + escapePointer(name), Value: toFiles[name]})
+		case content != toFiles[name]:
+			ops = append(ops, patchOp{Op: "replace", Path: "/files/" + escapePointer(name), Value: toFiles[name]})
+		}
+	}
+	return ops, nil
+}
+
+// diffValues - returns JSON6902 patch operations turning values from into values to. Maps are compared key by key,
+// other values including lists are replaced as a whole.
+func diffValues(path string, from, to interface{}) []patchOp {
+	fromMap, isFromMap := from.(map[string]interface{})
+	toMap, isToMap := to.(map[string]interface{})
+	if !isFromMap || !isToMap {
+		if reflect.DeepEqual(from, to) {
+			return nil
+		}
+		return []patchOp{{Op: "replace", Path: path, Value: to}}
+	}
+	keys := make([]string, 0, len(fromMap)+len(toMap))
+	for key := range fromMap {
+		keys = append(keys, key)
+	}
+	for key := range toMap {
+		if _, exists := fromMap[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var ops []patchOp
+	for _, key := range keys {
+		keyPath := path + "/" + escapePointer(key)
+		fromVal, inFrom := fromMap[key]
+		toVal, inTo := toMap[key]
+		switch {
+		case !inTo:
+			ops = append(ops, patchOp{Op: "remove", Path: keyPath})
+		case !inFrom:
+			ops = append(ops, patchOp{Op: "add", Path: keyPath, Value: toVal})
+		default:
+			ops = append(ops, diffValues(keyPath, fromVal, toVal)...)
+		}
+	}
+	return ops
+}
+
+// chartFiles - returns content of files in given dir by slash separated path relative to it. Missing dir has no files.
+func chartFiles(dir string) (map[string]string, error) {
+	res := map[string]string{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == dir {
+			return fs.SkipDir
+		}
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		res[filepath.ToSlash(rel)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read chart files in %s", err, dir)
+	}
+	return res, nil
+}
+
+// escapePointer - escapes JSON pointer reference token, see RFC 6901.
+func escapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
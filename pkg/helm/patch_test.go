@@ -0,0 +1,59 @@
+package helm
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_createPatch(t *testing.T) {
+	conf := config.Config{ChartDir: t.TempDir(), ChartName: "chart"}
+	web := func(replicas int64) helmify.Values {
+		return helmify.Values{"web": map[string]interface{}{"replicas": replicas, "image": map[string]interface{}{"tag": "1.0"}}}
+	}
+	err := NewOutput().Create(conf, []helmify.Template{valuesTemplate{values: web(1)}}, []string{"deployment.yaml"})
+	assert.NoError(t, err)
+	valuesFile := filepath.Join(conf.ChartDir, "chart", "values.yaml")
+	before, err := os.ReadFile(valuesFile)
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	templates := []helmify.Template{valuesTemplate{values: web(2)}, valuesTemplate{}}
+	err = output{}.createPatch(conf, templates, []string{"deployment.yaml", "service.yaml"}, &buf)
+	assert.NoError(t, err)
+
+	var ops []map[string]interface{}
+	assert.NoError(t, yaml.Unmarshal(buf.Bytes(), &ops))
+	assert.Equal(t, []map[string]interface{}{
+		{"op": "replace", "path": "/values/web/replicas", "value": float64(2)},
+		{"op": "add", "path": "/files/templates~1service.yaml", "value": validTemplate},
+	}, ops)
+	after, err := os.ReadFile(valuesFile)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(after), "chart is not changed")
+	assert.NoFileExists(t, filepath.Join(conf.ChartDir, "chart", "templates", "service.yaml"))
+}
+
+func Test_diffValues(t *testing.T) {
+	from := map[string]interface{}{
+		"web":   map[string]interface{}{"replicas": 1, "args": []interface{}{"-v"}, "debug": true},
+		"a/b~c": "old",
+	}
+	to := map[string]interface{}{
+		"web":   map[string]interface{}{"replicas": 1, "args": []interface{}{"-v", "-q"}},
+		"a/b~c": "",
+		"new":   map[string]interface{}{"enabled": false},
+	}
+	assert.Equal(t, []patchOp{
+		{Op: "replace", Path: "/values/a~1b~0c", Value: ""},
+		{Op: "add", Path: "/values/new", Value: map[string]interface{}{"enabled": false}},
+		{Op: "replace", Path: "/values/web/args", Value: []interface{}{"-v", "-q"}},
+		{Op: "remove", Path: "/values/web/debug"},
+	}, diffValues("/values", from, to))
+}
@@ -0,0 +1,115 @@
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/sirupsen/logrus"
+)
+
+const readmeHeader = `# %[1]s
+
+A Helm chart for Kubernetes
+
+## Values
+
+| Key | Type | Default | Source |
+|-----|------|---------|--------|
+`
+
+// valueSources - maps dot-separated value path to template files where the value is used.
+type valueSources map[string][]string
+
+func (s valueSources) add(values helmify.Values, filename string) {
+next:
+	for _, path := range flattenValues(values, "") {
+		for _, existing := range s[path.key] {
+			if existing == filename {
+				continue next
+			}
+		}
+		s[path.key] = append(s[path.key], filename)
+	}
+}
+
+type valuePath struct {
+	key   string
+	value interface{}
+}
+
+// flattenValues - returns leaf values with their dot-separated paths sorted by path.
+// Slices and empty maps are treated as leaves.
+func flattenValues(values map[string]interface{}, prefix string) []valuePath {
+	var res []valuePath
+	for k, v := range values {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]interface{}); ok && len(nested) != 0 {
+			res = append(res, flattenValues(nested, key)...)
+			continue
+		}
+		if nested, ok := v.(helmify.Values); ok && len(nested) != 0 {
+			res = append(res, flattenValues(nested, key)...)
+			continue
+		}
+		res = append(res, valuePath{key: key, value: v})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].key < res[j].key
+	})
+	return res
+}
+
+func valueType(value interface{}) string {
+	switch value.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case int, int8, int16, int32, int64:
+		return "int"
+	case float32, float64:
+		return "float"
+	case []interface{}, []string:
+		return "list"
+	default:
+		return "object"
+	}
+}
+
+func readmeMD(chartName string, values helmify.Values, sources valueSources) ([]byte, error) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(readmeHeader, chartName))
+	for _, path := range flattenValues(values, "") {
+		def, err := json.Marshal(path.value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to marshal default for %s", err, path.key)
+		}
+		source := "-"
+		if files := sources[path.key]; len(files) != 0 {
+			source = strings.Join(files, ", ")
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s |\n", path.key, valueType(path.value), strings.ReplaceAll(string(def), "|", "\\|"), source))
+	}
+	return []byte(sb.String()), nil
+}
+
+func overwriteReadmeFile(chartDir, chartName string, values helmify.Values, sources valueSources, transform transformers) error {
+	res, err := readmeMD(chartName, values, sources)
+	if err != nil {
+		return err
+	}
+	file := filepath.Join(chartDir, "README.md")
+	err = transform.writeFile(chartDir, file, res)
+	if err != nil {
+		return err
+	}
+	logrus.WithField("file", file).Info("overwritten")
+	return nil
+}
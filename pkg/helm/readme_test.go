@@ -0,0 +1,34 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_readmeMD(t *testing.T) {
+	values := helmify.Values{
+		"kubernetesClusterDomain": "cluster.local",
+		"web": map[string]interface{}{
+			"replicas": int64(3),
+			"web": map[string]interface{}{
+				"image": map[string]interface{}{
+					"repository": "nginx",
+					"tag":        "1.25.0",
+				},
+				"args": []interface{}{"--a"},
+			},
+		},
+	}
+	sources := valueSources{}
+	sources.add(helmify.Values{"web": values["web"]}, "deployment.yaml")
+
+	res, err := readmeMD("chart", values, sources)
+	assert.NoError(t, err)
+	assert.Contains(t, string(res), "# chart\n")
+	assert.Contains(t, string(res), "| web.web.image.repository | string | `\"nginx\"` | deployment.yaml |\n")
+	assert.Contains(t, string(res), "| web.replicas | int | `3` | deployment.yaml |\n")
+	assert.Contains(t, string(res), "| web.web.args | list | `[\"--a\"]` | deployment.yaml |\n")
+	assert.Contains(t, string(res), "| kubernetesClusterDomain | string | `\"cluster.local\"` | - |\n")
+}
@@ -0,0 +1,104 @@
+package helm
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+)
+
+// Transformer - post-render hook changing content of a generated chart file before it is written, e.g. for custom
+// formatting or organization headers. Filename is relative to the chart directory, e.g. templates/deployment.yaml.
+type Transformer func(filename string, content []byte) ([]byte, error)
+
+// LicenseHeader - returns Transformer prepending given header to yaml files as a comment.
+func LicenseHeader(header string) Transformer {
+	var comment strings.Builder
+	for _, line := range strings.Split(strings.TrimRight(header, "\n"), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			line = strings.TrimRight("# "+line, " ")
+		}
+		comment.WriteString(line + "\n")
+	}
+	return func(filename string, content []byte) ([]byte, error) {
+		if ext := filepath.Ext(filename); ext != ".yaml" && ext != ".yml" {
+			return content, nil
+		}
+		return append([]byte(comment.String()), content...), nil
+	}
+}
+
+// Indentation - returns Transformer changing indentation width of yaml files, see yamlformat.Reindent.
+func Indentation(width int) Transformer {
+	return func(filename string, content []byte) ([]byte, error) {
+		if ext := filepath.Ext(filename); ext != ".yaml" && ext != ".yml" {
+			return content, nil
+		}
+		return yamlformat.Reindent(content, width), nil
+	}
+}
+
+// Command - returns Transformer piping file content through given shell command and using its output as new content.
+// File name is passed to the command in HELMIFY_FILE environment variable.
+func Command(command string) Transformer {
+	return func(filename string, content []byte) ([]byte, error) {
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Env = append(os.Environ(), "HELMIFY_FILE="+filename)
+		cmd.Stdin = bytes.NewReader(content)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("%w: post-render command %q failed for %s: %s", err, command, filename, stderr.String())
+		}
+		return out, nil
+	}
+}
+
+// transformers - post-render hooks applied in order.
+type transformers []Transformer
+
+// withConfig - returns transformers followed by the ones enabled in config. Indentation is changed first to
+// give other transformers the final layout.
+func (t transformers) withConfig(conf config.Config) (transformers, error) {
+	var res transformers
+	if conf.IndentWidth != 0 && conf.IndentWidth != yamlformat.DefaultIndent {
+		res = append(res, Indentation(conf.IndentWidth))
+	}
+	res = append(res, t...)
+	if conf.LicenseHeaderFile != "" {
+		header, err := os.ReadFile(conf.LicenseHeaderFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unable to read license header file %s", err, conf.LicenseHeaderFile)
+		}
+		res = append(res, LicenseHeader(string(header)))
+	}
+	for _, command := range conf.PostRenderCommands {
+		res = append(res, Command(command))
+	}
+	return res, nil
+}
+
+// writeFile - applies transformers to content and writes it to file in chartDir.
+func (t transformers) writeFile(chartDir, file string, content []byte) error {
+	rel, err := filepath.Rel(chartDir, file)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get path of %s in chart", err, file)
+	}
+	rel = filepath.ToSlash(rel)
+	for _, transform := range t {
+		content, err = transform(rel, content)
+		if err != nil {
+			return err
+		}
+	}
+	if err = os.WriteFile(file, content, 0600); err != nil {
+		return fmt.Errorf("%w: unable to write %s", err, file)
+	}
+	return nil
+}
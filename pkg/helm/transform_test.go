@@ -0,0 +1,66 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreate_transformers(t *testing.T) {
+	conf := config.Config{ChartDir: t.TempDir(), ChartName: "chart"}
+	var transformed []string
+	header := func(filename string, content []byte) ([]byte, error) {
+		transformed = append(transformed, filename)
+		return append([]byte("# Copyright Acme\n"), content...), nil
+	}
+	templates := []helmify.Template{testTemplate{}, testTemplate{}}
+	filenames := []string{"config.yaml", "other.yaml"}
+
+	err := NewOutput(header).Create(conf, templates, filenames)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"templates/config.yaml", "templates/other.yaml", "values.yaml"}, transformed)
+	for _, file := range filenames {
+		content, err := os.ReadFile(filepath.Join(conf.ChartDir, "chart", "templates", file))
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "# Copyright Acme\napiVersion: v1\n"), string(content))
+	}
+	assert.NoError(t, validateChart(filepath.Join(conf.ChartDir, "chart")))
+}
+
+func TestLicenseHeader(t *testing.T) {
+	transform := LicenseHeader("Copyright Acme\n\n# Licensed under MIT\n")
+	res, err := transform("templates/config.yaml", []byte("kind: ConfigMap\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Copyright Acme\n#\n# Licensed under MIT\nkind: ConfigMap\n", string(res))
+
+	res, err = transform("README.md", []byte("# Chart\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# Chart\n", string(res), "only yaml files changed")
+}
+
+func TestCommand(t *testing.T) {
+	res, err := Command(`echo "# $HELMIFY_FILE"; cat`)("templates/config.yaml", []byte("kind: ConfigMap\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# templates/config.yaml\nkind: ConfigMap\n", string(res))
+
+	_, err = Command("echo broken >&2; exit 1")("values.yaml", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "broken")
+	}
+}
+
+func TestTransformers_withConfig(t *testing.T) {
+	headerFile := filepath.Join(t.TempDir(), "header.txt")
+	assert.NoError(t, os.WriteFile(headerFile, []byte("Copyright Acme"), 0600))
+	res, err := transformers{}.withConfig(config.Config{LicenseHeaderFile: headerFile, PostRenderCommands: []string{"cat"}})
+	assert.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	_, err = transformers{}.withConfig(config.Config{LicenseHeaderFile: filepath.Join(t.TempDir(), "missing")})
+	assert.Error(t, err)
+}
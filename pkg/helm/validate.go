@@ -0,0 +1,60 @@
+package helm
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"sigs.k8s.io/yaml"
+)
+
+// validateChart - renders chart from chartDir the same way as 'helm template' does and checks that every
+// rendered manifest is a valid yaml. Returns all found errors.
+func validateChart(chartDir string) error {
+	chrt, err := loader.Load(chartDir)
+	if err != nil {
+		return fmt.Errorf("%w: unable to load chart %s", err, chartDir)
+	}
+	vals, err := chartutil.ToRenderValues(chrt, map[string]interface{}{}, chartutil.ReleaseOptions{
+		Name:      "helmify-validate",
+		Namespace: "helmify-validate",
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("%w: unable to prepare render values", err)
+	}
+	// lint mode: do not fail on 'required' values, e.g. empty secrets.
+	rendered, err := engine.Engine{LintMode: true}.Render(chrt, vals)
+	if err != nil {
+		return fmt.Errorf("%w: chart rendering failed", err)
+	}
+
+	files := make([]string, 0, len(rendered))
+	for file := range rendered {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	var errs []error
+	for _, file := range files {
+		if strings.HasSuffix(file, "NOTES.txt") || strings.HasPrefix(filepath.Base(file), "_") {
+			continue
+		}
+		for _, manifest := range releaseutil.SplitManifests(rendered[file]) {
+			obj := map[string]interface{}{}
+			if err = yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+				errs = append(errs, fmt.Errorf("%w: invalid yaml rendered from %s", err, file))
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return errors.Join(errs...)
+	}
+	logrus.WithField("chart", chartDir).Info("validated")
+	return nil
+}
@@ -0,0 +1,57 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const validTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "chart.fullname" . }}-config
+  labels:
+  {{- include "chart.labels" . | nindent 4 }}
+data:
+  key: {{ .Values.key | quote }}`
+
+const brokenTemplate = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "chart.fullname" . }}-config
+  labels:
+  {{- include "chart.labels" . | nindent 1 }}
+data:
+  key: {{ .Values.key | quote }}`
+
+func Test_validateChart(t *testing.T) {
+	t.Run("valid chart", func(t *testing.T) {
+		chartDir := createTestChart(t, validTemplate)
+		assert.NoError(t, validateChart(chartDir))
+	})
+	t.Run("broken indentation flagged", func(t *testing.T) {
+		chartDir := createTestChart(t, brokenTemplate)
+		err := validateChart(chartDir)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "chart/templates/config.yaml")
+		}
+	})
+	t.Run("missing helper flagged", func(t *testing.T) {
+		chartDir := createTestChart(t, `name: {{ include "chart.unknown" . }}`)
+		assert.Error(t, validateChart(chartDir))
+	})
+}
+
+func createTestChart(t *testing.T, template string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := initChartDir(config.Config{ChartDir: dir, ChartName: "chart"})
+	assert.NoError(t, err)
+	chartDir := filepath.Join(dir, "chart")
+	assert.NoError(t, os.WriteFile(filepath.Join(chartDir, "values.yaml"), []byte("key: value\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(chartDir, "templates", "config.yaml"), []byte(template), 0600))
+	return chartDir
+}
@@ -5,7 +5,10 @@ import (
 
 	"github.com/arttor/helmify/pkg/config"
 
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // Processor - converts k8s object to helm template.
@@ -28,7 +31,7 @@ type Template interface {
 
 // Output - converts Template into helm chart on disk.
 type Output interface {
-	Create(chartName, chartDir string, Crd bool, certManagerAsSubchart bool, certManagerVersion string, templates []Template, filenames []string) error
+	Create(conf config.Config, templates []Template, filenames []string) error
 }
 
 // AppMetadata handle common information about K8s objects in the chart.
@@ -47,6 +50,11 @@ type AppMetadata interface {
 	// TrimName trims common prefix from object name if exists.
 	// We trim common prefix because helm already using release for this purpose.
 	TrimName(objName string) string
+	// CRDSchema returns OpenAPI schema of custom resource with given GroupVersionKind if its
+	// CustomResourceDefinition is in the input.
+	CRDSchema(gvk schema.GroupVersionKind) (*apiextensionsv1.JSONSchemaProps, bool)
+	// ServicePorts returns ports of Service with given name if it is in the input.
+	ServicePorts(name string) ([]corev1.ServicePort, bool)
 
 	Config() config.Config
 }
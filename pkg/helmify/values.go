@@ -2,15 +2,21 @@ package helmify
 
 import (
 	"dario.cat/mergo"
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/iancoleman/strcase"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// ErrUnsupportedValue - returned when value of unsupported type is added to values.
+var ErrUnsupportedValue = errors.New("unsupported value type")
+
 // Values - represents helm template values.yaml.
 type Values map[string]interface{}
 
@@ -22,34 +28,71 @@ func (v *Values) Merge(values Values) error {
 	return nil
 }
 
+// Override - deep merges given values over current instance. Given values take precedence, lists are replaced.
+func (v *Values) Override(values Values) error {
+	if err := mergo.Merge(v, values, mergo.WithOverride); err != nil {
+		return fmt.Errorf("%w: unable to override helm values", err)
+	}
+	return nil
+}
+
 // Add - adds given value to values and returns its helm template representation {{ .Values.<valueName> }}
+// Go integer and float types are converted to int64 and float64. Returns ErrUnsupportedValue for other types.
 func (v *Values) Add(value interface{}, name ...string) (string, error) {
 	name = toCamelCase(name)
-	switch val := value.(type) {
-	case int:
-		value = int64(val)
-	case int8:
-		value = int64(val)
-	case int16:
-		value = int64(val)
-	case int32:
-		value = int64(val)
+	value, err := jsonValue(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to set value: %v", err, name)
 	}
 
-	err := unstructured.SetNestedField(*v, value, name...)
+	err = unstructured.SetNestedField(*v, value, name...)
 	if err != nil {
 		return "", fmt.Errorf("%w: unable to set value: %v", err, name)
 	}
 	_, isString := value.(string)
 	if isString {
-		return "{{ .Values." + strings.Join(name, ".") + " | quote }}", nil
+		return "{{ " + valuesRef(name) + " | quote }}", nil
 	}
 	_, isSlice := value.([]interface{})
 	if isSlice {
 		spaces := strconv.Itoa(len(name) * 2)
-		return "{{ toYaml .Values." + strings.Join(name, ".") + " | nindent " + spaces + " }}", nil
+		return "{{ toYaml " + valuesRef(name) + " | nindent " + spaces + " }}", nil
 	}
-	return "{{ .Values." + strings.Join(name, ".") + " }}", nil
+	return "{{ " + valuesRef(name) + " }}", nil
+}
+
+// jsonValue - converts given scalar value to type supported in values, e.g. int or uint32 to int64, float32 to
+// float64.
+func jsonValue(value interface{}) (interface{}, error) {
+	switch val := value.(type) {
+	case string, bool, int64, float64, nil, []interface{}, map[string]interface{}:
+		return value, nil
+	case int:
+		return int64(val), nil
+	case int8:
+		return int64(val), nil
+	case int16:
+		return int64(val), nil
+	case int32:
+		return int64(val), nil
+	case uint:
+		return jsonValue(uint64(val))
+	case uint8:
+		return int64(val), nil
+	case uint16:
+		return int64(val), nil
+	case uint32:
+		return int64(val), nil
+	case uint64:
+		if val > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %d overflows int64", ErrUnsupportedValue, val)
+		}
+		return int64(val), nil
+	case float32:
+		// shortest decimal representation keeps 0.1 instead of 0.10000000149011612
+		return strconv.ParseFloat(strconv.FormatFloat(float64(val), 'g', -1, 32), 64)
+	}
+	return nil, fmt.Errorf("%w: %T", ErrUnsupportedValue, value)
 }
 
 // AddYaml - adds given value to values and returns its helm template representation as Yaml {{ .Values.<valueName> | toYaml | indent i }}
@@ -62,11 +105,11 @@ func (v *Values) AddYaml(value interface{}, indent int, newLine bool, name ...st
 	}
 	if indent > 0 {
 		if newLine {
-			return "{{ .Values." + strings.Join(name, ".") + fmt.Sprintf(" | toYaml | nindent %d }}", indent), nil
+			return "{{ " + valuesRef(name) + fmt.Sprintf(" | toYaml | nindent %d }}", indent), nil
 		}
-		return "{{ .Values." + strings.Join(name, ".") + fmt.Sprintf(" | toYaml | indent %d }}", indent), nil
+		return "{{ " + valuesRef(name) + fmt.Sprintf(" | toYaml | indent %d }}", indent), nil
 	}
-	return "{{ .Values." + strings.Join(name, ".") + " | toYaml }}", nil
+	return "{{ " + valuesRef(name) + " | toYaml }}", nil
 }
 
 // AddSecret - adds empty value to values and returns its helm template representation {{ required "<valueName>" .Values.<valueName> }}.
@@ -78,13 +121,53 @@ func (v *Values) AddSecret(toBase64 bool, name ...string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("%w: unable to set value: %v", err, nameStr)
 	}
-	res := fmt.Sprintf(`{{ required "%[1]s is required" .Values.%[1]s`, nameStr)
+	res := fmt.Sprintf(`{{ required "%s is required" %s`, nameStr, valuesRef(name))
 	if toBase64 {
 		res += " | b64enc"
 	}
 	return res + " | quote }}", err
 }
 
+// AddSecretPlaintext - adds given plaintext secret value to values and returns its helm template representation
+// {{ .Values.<valueName> | b64enc | quote }}. Value is base64 encoded on render so it can be overridden as plaintext.
+func (v *Values) AddSecretPlaintext(value string, name ...string) (string, error) {
+	name = toCamelCase(name)
+	nameStr := strings.Join(name, ".")
+	err := unstructured.SetNestedField(*v, value, name...)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to set value: %v", err, nameStr)
+	}
+	return fmt.Sprintf(`{{ %s | b64enc | quote }}`, valuesRef(name)), nil
+}
+
+// valuesRef - returns template reference to the value with the given path, e.g. '.Values.a.b'.
+// Uses index function if path contains names not allowed in template field chain, e.g. starting with a digit.
+func valuesRef(name []string) string {
+	for _, n := range name {
+		if !isIdentifier(n) {
+			quoted := make([]string, len(name))
+			for i := range name {
+				quoted[i] = strconv.Quote(name[i])
+			}
+			return "(index .Values " + strings.Join(quoted, " ") + ")"
+		}
+	}
+	return ".Values." + strings.Join(name, ".")
+}
+
+// isIdentifier - returns true if name can be used as a field name in go template.
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && !unicode.IsLetter(r) && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
+}
+
 func toCamelCase(name []string) []string {
 	for i, n := range name {
 		camelCase := strcase.ToLowerCamel(n)
@@ -1,7 +1,11 @@
 package helmify
 
 import (
+	"math"
+	"strconv"
+	"strings"
 	"testing"
+	"text/template"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -25,6 +29,29 @@ func TestValues_Add(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotContains(t, res, "quote")
 	})
+	t.Run("go numeric types converted", func(t *testing.T) {
+		testVal := Values{}
+		for name, val := range map[string]interface{}{
+			"int": 1, "int32": int32(2), "uint": uint(3), "uint8": uint8(4), "uint64": uint64(5), "float32": float32(0.1),
+		} {
+			res, err := testVal.Add(val, "a", name)
+			assert.NoError(t, err)
+			assert.Equal(t, "{{ .Values.a."+name+" }}", res)
+		}
+		assert.Equal(t, Values{"a": map[string]interface{}{
+			"int": int64(1), "int32": int64(2), "uint": int64(3), "uint8": int64(4), "uint64": int64(5), "float32": 0.1,
+		}}, testVal)
+	})
+	t.Run("unsupported types fail", func(t *testing.T) {
+		testVal := Values{}
+		_, err := testVal.Add(uint64(math.MaxUint64), "a")
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+		_, err = testVal.Add(struct{}{}, "b")
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+		_, err = testVal.Add([]string{"c"}, "c")
+		assert.ErrorIs(t, err, ErrUnsupportedValue)
+		assert.Empty(t, testVal)
+	})
 	t.Run("name path is dot formatted", func(t *testing.T) {
 		testVal := Values{}
 		res, err := testVal.Add(int64(1), "a", "b")
@@ -82,3 +109,71 @@ func TestValues_AddSecret(t *testing.T) {
 		assert.NotContains(t, res, "b64enc")
 	})
 }
+func TestValues_AddSecretPlaintext(t *testing.T) {
+	testVal := Values{}
+	res, err := testVal.AddSecretPlaintext("log: debug", "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, "{{ .Values.a.b | b64enc | quote }}", res)
+	assert.Equal(t, Values{"a": map[string]interface{}{"b": "log: debug"}}, testVal)
+}
+
+func TestValues_Override(t *testing.T) {
+	testVal := Values{
+		"web": map[string]interface{}{
+			"replicas": int64(3),
+			"args":     []interface{}{"--a", "--b"},
+			"image":    map[string]interface{}{"repository": "nginx", "tag": "1.25.0"},
+		},
+	}
+	err := testVal.Override(Values{
+		"web": map[string]interface{}{
+			"replicas": int64(1),
+			"args":     []interface{}{"--c"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Values{
+		"web": map[string]interface{}{
+			"replicas": int64(1),
+			"args":     []interface{}{"--c"},
+			"image":    map[string]interface{}{"repository": "nginx", "tag": "1.25.0"},
+		},
+	}, testVal)
+}
+
+func TestValues_invalidIdentifiers(t *testing.T) {
+	t.Run("dashed name is camel cased", func(t *testing.T) {
+		testVal := Values{}
+		res, err := testVal.Add("true", "config", "leader-elect")
+		assert.NoError(t, err)
+		assert.Equal(t, "{{ .Values.config.leaderElect | quote }}", res)
+	})
+	t.Run("index function used for name starting with digit", func(t *testing.T) {
+		testVal := Values{}
+		res, err := testVal.Add("4", "config", "2-workers")
+		assert.NoError(t, err)
+		assert.Equal(t, `{{ (index .Values "config" "2Workers") | quote }}`, res)
+		assertRenders(t, res, testVal, `"4"`)
+
+		res, err = testVal.AddSecret(true, "secret", "1")
+		assert.NoError(t, err)
+		assert.Equal(t, `{{ required "secret.1 is required" (index .Values "secret" "1") | b64enc | quote }}`, res)
+
+		res, err = testVal.AddYaml("a: b", 2, true, "config", "9.yaml")
+		assert.NoError(t, err)
+		assert.Equal(t, `{{ (index .Values "config" "9Yaml") | toYaml | nindent 2 }}`, res)
+	})
+}
+
+// assertRenders - checks that tmpl is a valid go template rendering expected output with given values.
+func assertRenders(t *testing.T, tmpl string, values Values, expected string) {
+	t.Helper()
+	parsed, err := template.New("test").Funcs(template.FuncMap{"quote": strconv.Quote}).Parse(tmpl)
+	if !assert.NoError(t, err) {
+		return
+	}
+	buf := strings.Builder{}
+	err = parsed.Execute(&buf, map[string]interface{}{"Values": map[string]interface{}(values)})
+	assert.NoError(t, err)
+	assert.Equal(t, expected, buf.String())
+}
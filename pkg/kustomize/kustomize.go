@@ -0,0 +1,186 @@
+// Package kustomize contains code for writing processed k8s objects to a filesystem as Kustomize base and overlay.
+package kustomize
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kustomizationFile = "kustomization.yaml"
+	baseDir           = "base"
+	overlayDir        = "overlay"
+)
+
+// kinds which names are not prefixed by Kustomize namePrefix, so they are kept as is.
+var notPrefixedKinds = map[string]bool{
+	"CustomResourceDefinition": true,
+	"Namespace":                true,
+}
+
+// podSpecPaths - path to pod spec for workload kinds.
+var podSpecPaths = map[string][]string{
+	"Deployment":  {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+type kustomization struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Resources  []string  `json:"resources"`
+	NamePrefix string    `json:"namePrefix,omitempty"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Images     []image   `json:"images,omitempty"`
+	Replicas   []replica `json:"replicas,omitempty"`
+}
+
+type image struct {
+	Name   string `json:"name"`
+	NewTag string `json:"newTag,omitempty"`
+}
+
+type replica struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+func newKustomization(resources ...string) kustomization {
+	return kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  resources,
+	}
+}
+
+// Create writes objects as Kustomize base and overlay instead of a Helm chart:
+// chartName/
+//
+//	├── base/
+//	│   ├── kustomization.yaml	# Lists all resources
+//	│   └── <kind>-<name>.yaml	# Resource with trimmed name and without namespace
+//	└── overlay/
+//	    └── kustomization.yaml	# namePrefix, namespace, images and replicas: an equivalent of values.yaml
+//
+// Object names are trimmed the same way as for Helm templates and references between objects are updated.
+// Chart name is used as namePrefix instead of the chart fullname helper. Overwrites existing files on every run.
+func Create(conf config.Config, appMeta helmify.AppMetadata, objs []*unstructured.Unstructured) error {
+	dir := filepath.Join(conf.ChartDir, conf.ChartName)
+	for _, d := range []string{baseDir, overlayDir} {
+		if err := os.MkdirAll(filepath.Join(dir, d), 0750); err != nil {
+			return fmt.Errorf("%w: unable create %s dir", err, d)
+		}
+	}
+	names := map[string]string{}
+	for _, obj := range objs {
+		if !notPrefixedKinds[obj.GetKind()] {
+			names[obj.GetName()] = appMeta.TrimName(obj.GetName())
+		}
+	}
+	base := newKustomization()
+	overlay := newKustomization("../" + baseDir)
+	overlay.NamePrefix = appMeta.ChartName() + "-"
+	overlay.Namespace = appMeta.Namespace()
+	images := map[string]bool{}
+	for _, obj := range objs {
+		if obj.GetKind() == "Namespace" {
+			// namespace is set by overlay.
+			continue
+		}
+		res := obj.DeepCopy()
+		res.SetNamespace("")
+		res.Object = rename(res.Object, names).(map[string]interface{})
+		overlay.Images = append(overlay.Images, podImages(res, images)...)
+		if count, ok, _ := unstructured.NestedInt64(res.Object, "spec", "replicas"); ok {
+			overlay.Replicas = append(overlay.Replicas, replica{Name: res.GetName(), Count: count})
+		}
+		file := strings.ToLower(res.GetKind()) + "-" + res.GetName() + ".yaml"
+		if err := writeYaml(filepath.Join(dir, baseDir, file), res.Object); err != nil {
+			return err
+		}
+		base.Resources = append(base.Resources, file)
+	}
+	if err := writeYaml(filepath.Join(dir, baseDir, kustomizationFile), base); err != nil {
+		return err
+	}
+	return writeYaml(filepath.Join(dir, overlayDir, kustomizationFile), overlay)
+}
+
+// rename - replaces all string values equal to the original object names with trimmed names.
+func rename(val interface{}, names map[string]string) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = rename(item, names)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = rename(item, names)
+		}
+	case string:
+		if trimmed, ok := names[v]; ok {
+			return trimmed
+		}
+	}
+	return val
+}
+
+// podImages - returns images of obj pod containers not in seen yet. Kustomize matches images by name without tag.
+func podImages(obj *unstructured.Unstructured, seen map[string]bool) []image {
+	path, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil
+	}
+	var res []image
+	for _, containerType := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(obj.Object, append(path, containerType)...)
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ref, _, _ := unstructured.NestedString(container, "image")
+			name, tag := splitImage(ref)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			res = append(res, image{Name: name, NewTag: tag})
+		}
+	}
+	return res
+}
+
+// splitImage - splits image reference 'name[:tag]' to its parts. Returns empty name for images pinned by digest.
+func splitImage(ref string) (name, tag string) {
+	if strings.Contains(ref, "@") {
+		return "", ""
+	}
+	// colon before the last slash belongs to registry host port
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[:i], ref[i+1:]
+	}
+	return ref, ""
+}
+
+func writeYaml(file string, obj interface{}) error {
+	res, err := yaml.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("%w: unable to marshal %s", err, file)
+	}
+	if err = os.WriteFile(file, res, 0600); err != nil {
+		return fmt.Errorf("%w: unable to write %s", err, file)
+	}
+	logrus.WithField("file", file).Info("overwritten")
+	return nil
+}
@@ -0,0 +1,102 @@
+package kustomize
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+const strDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+  namespace: my-app
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: registry.local:5000/nginx:1.25.0
+        envFrom:
+        - configMapRef:
+            name: my-app-config`
+
+const strConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-app-config
+  namespace: my-app
+data:
+  key: value`
+
+func TestCreate(t *testing.T) {
+	conf := config.Config{ChartName: "my-app", ChartDir: t.TempDir(), OutputFormat: config.OutputFormatKustomize}
+	objs := []*unstructured.Unstructured{internal.GenerateObj(strConfigMap), internal.GenerateObj(strDeployment)}
+	appMeta := metadata.New(conf)
+	for _, obj := range objs {
+		appMeta.Load(obj)
+	}
+	err := Create(conf, appMeta, objs)
+	assert.NoError(t, err)
+	dir := filepath.Join(conf.ChartDir, conf.ChartName)
+
+	base := kustomization{}
+	readYaml(t, filepath.Join(dir, "base", "kustomization.yaml"), &base)
+	assert.Equal(t, []string{"configmap-config.yaml", "deployment-web.yaml"}, base.Resources)
+	for _, res := range base.Resources {
+		assert.FileExists(t, filepath.Join(dir, "base", res))
+	}
+
+	overlay := kustomization{}
+	readYaml(t, filepath.Join(dir, "overlay", "kustomization.yaml"), &overlay)
+	assert.Equal(t, kustomization{
+		APIVersion: "kustomize.config.k8s.io/v1beta1",
+		Kind:       "Kustomization",
+		Resources:  []string{"../base"},
+		NamePrefix: "my-app-",
+		Namespace:  "my-app",
+		Images:     []image{{Name: "registry.local:5000/nginx", NewTag: "1.25.0"}},
+		Replicas:   []replica{{Name: "web", Count: 3}},
+	}, overlay)
+
+	deployment := map[string]interface{}{}
+	readYaml(t, filepath.Join(dir, "base", "deployment-web.yaml"), &deployment)
+	obj := unstructured.Unstructured{Object: deployment}
+	assert.Equal(t, "web", obj.GetName())
+	assert.Empty(t, obj.GetNamespace())
+	containers, _, _ := unstructured.NestedSlice(deployment, "spec", "template", "spec", "containers")
+	envFrom, _, _ := unstructured.NestedSlice(containers[0].(map[string]interface{}), "envFrom")
+	assert.Equal(t, []interface{}{map[string]interface{}{"configMapRef": map[string]interface{}{"name": "config"}}}, envFrom)
+}
+
+func Test_splitImage(t *testing.T) {
+	name, tag := splitImage("localhost:5000/nginx")
+	assert.Equal(t, "localhost:5000/nginx", name)
+	assert.Empty(t, tag)
+	name, tag = splitImage("nginx:1.25.0")
+	assert.Equal(t, "nginx", name)
+	assert.Equal(t, "1.25.0", tag)
+	name, _ = splitImage("nginx@sha256:4f5e")
+	assert.Empty(t, name)
+}
+
+func readYaml(t *testing.T, file string, obj interface{}) {
+	t.Helper()
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.NoError(t, yaml.Unmarshal(content, obj))
+}
@@ -0,0 +1,209 @@
+// Package lint contains checks for common anti-patterns in k8s manifests converted to a Helm chart.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Warning - lint finding for a single object.
+type Warning struct {
+	Kind    string
+	Name    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s %s: %s", w.Kind, w.Name, w.Message)
+}
+
+// podSpecPaths - path to pod spec for workload kinds.
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// Check - returns lint warnings for given objects:
+//   - container images with 'latest' or without tag;
+//   - containers without resource limits;
+//   - namespaces other than the app namespace: objects are moved to release namespace, references stay hardcoded;
+//   - Deployments with multiple replicas sharing a PersistentVolumeClaim;
+//   - ClusterRoles identical to another ClusterRole or repeating its rules;
+//   - pods referencing Secrets, ConfigMaps or Services of the input in other namespaces.
+func Check(appMeta helmify.AppMetadata, objs []*unstructured.Unstructured) []Warning {
+	var res []Warning
+	refs := newNamespacedRefs(appMeta, objs)
+	for _, obj := range objs {
+		for _, msg := range check(appMeta, obj) {
+			res = append(res, Warning{Kind: obj.GetKind(), Name: obj.GetName(), Message: msg})
+		}
+		for _, msg := range refs.check(obj) {
+			res = append(res, Warning{Kind: obj.GetKind(), Name: obj.GetName(), Message: msg})
+		}
+	}
+	return append(res, checkDuplicateRules(objs)...)
+}
+
+func check(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) []string {
+	var res []string
+	res = append(res, checkNamespaces(appMeta, obj)...)
+	path, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return res
+	}
+	podSpec, ok, _ := unstructured.NestedMap(obj.Object, path...)
+	if !ok {
+		return res
+	}
+	for _, containerType := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(podSpec, containerType)
+		for _, c := range containers {
+			container, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			res = append(res, checkContainer(container)...)
+		}
+	}
+	if obj.GetKind() == "Deployment" {
+		res = append(res, checkSharedClaims(obj, podSpec)...)
+	}
+	return res
+}
+
+func checkContainer(container map[string]interface{}) []string {
+	var res []string
+	name, _, _ := unstructured.NestedString(container, "name")
+	image, _, _ := unstructured.NestedString(container, "image")
+	if isLatest(image) {
+		res = append(res, fmt.Sprintf("container %s uses image %q without a fixed tag", name, image))
+	}
+	if limits, _, _ := unstructured.NestedMap(container, "resources", "limits"); len(limits) == 0 {
+		res = append(res, fmt.Sprintf("container %s has no resource limits", name))
+	}
+	return res
+}
+
+// isLatest - returns true if image reference is not pinned to a tag or a digest other than 'latest'.
+func isLatest(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	i := strings.LastIndex(image, ":")
+	if i <= strings.LastIndex(image, "/") {
+		return true
+	}
+	return image[i+1:] == "latest"
+}
+
+func checkSharedClaims(obj *unstructured.Unstructured, podSpec map[string]interface{}) []string {
+	replicas, ok, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+	if ok && replicas <= 1 {
+		return nil
+	}
+	var res []string
+	volumes, _, _ := unstructured.NestedSlice(podSpec, "volumes")
+	for _, v := range volumes {
+		volume, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if claim, ok, _ := unstructured.NestedString(volume, "persistentVolumeClaim", "claimName"); ok {
+			res = append(res, fmt.Sprintf("persistent volume claim %s is shared between replicas, consider StatefulSet with volumeClaimTemplates", claim))
+		}
+	}
+	return res
+}
+
+func checkNamespaces(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) []string {
+	var res []string
+	if ns := obj.GetNamespace(); ns != "" && ns != appMeta.Namespace() {
+		res = append(res, fmt.Sprintf("namespace %s differs from the app namespace %s, object is moved to the release namespace", ns, appMeta.Namespace()))
+	}
+	subjects, _, _ := unstructured.NestedSlice(obj.Object, "subjects")
+	for _, s := range subjects {
+		subject, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ns, _, _ := unstructured.NestedString(subject, "namespace")
+		if ns != "" && ns != appMeta.Namespace() {
+			name, _, _ := unstructured.NestedString(subject, "name")
+			res = append(res, fmt.Sprintf("subject %s references hardcoded namespace %s", name, ns))
+		}
+	}
+	webhooks, _, _ := unstructured.NestedSlice(obj.Object, "webhooks")
+	for _, w := range webhooks {
+		webhook, ok := w.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ns, _, _ := unstructured.NestedString(webhook, "clientConfig", "service", "namespace")
+		if ns != "" && ns != appMeta.Namespace() {
+			name, _, _ := unstructured.NestedString(webhook, "name")
+			res = append(res, fmt.Sprintf("webhook %s references service in hardcoded namespace %s", name, ns))
+		}
+	}
+	return res
+}
+
+// checkDuplicateRules - reports ClusterRoles with the same rules as a previous ClusterRole and rules repeated from a
+// previous ClusterRole. Rules are compared ignoring order of their lists. Roles are not merged as they may be bound
+// to different subjects.
+func checkDuplicateRules(objs []*unstructured.Unstructured) []Warning {
+	var res []Warning
+	roles := map[string]string{}
+	rules := map[string]string{}
+	for _, obj := range objs {
+		if obj.GetKind() != "ClusterRole" || obj.GroupVersionKind().Group != rbacv1.GroupName {
+			continue
+		}
+		role := rbacv1.ClusterRole{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &role); err != nil || len(role.Rules) == 0 {
+			continue
+		}
+		keys := make([]string, 0, len(role.Rules))
+		for _, rule := range role.Rules {
+			keys = append(keys, ruleKey(rule))
+		}
+		sort.Strings(keys)
+		roleKey := strings.Join(keys, "\n")
+		if other, ok := roles[roleKey]; ok {
+			res = append(res, Warning{Kind: obj.GetKind(), Name: obj.GetName(), Message: fmt.Sprintf("rules are identical to ClusterRole %s", other)})
+			continue
+		}
+		roles[roleKey] = obj.GetName()
+		for i, key := range keys {
+			if i > 0 && keys[i-1] == key {
+				continue
+			}
+			if other, ok := rules[key]; ok {
+				res = append(res, Warning{Kind: obj.GetKind(), Name: obj.GetName(), Message: fmt.Sprintf("rule %s duplicates rule of ClusterRole %s", key, other)})
+				continue
+			}
+			rules[key] = obj.GetName()
+		}
+	}
+	return res
+}
+
+// ruleKey - returns canonical form of policy rule with sorted lists.
+func ruleKey(rule rbacv1.PolicyRule) string {
+	for _, list := range [][]string{rule.APIGroups, rule.Resources, rule.ResourceNames, rule.NonResourceURLs, rule.Verbs} {
+		sort.Strings(list)
+	}
+	key, _ := json.Marshal(rule)
+	return string(key)
+}
@@ -0,0 +1,197 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const strDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+  namespace: my-app
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:latest
+      - name: sidecar
+        image: registry.local:5000/sidecar
+      - name: pinned
+        image: registry.local:5000/app:1.2.3
+        resources:
+          limits:
+            cpu: 100m
+      volumes:
+      - name: data
+        persistentVolumeClaim:
+          claimName: my-app-data`
+
+const strRoleBinding = `apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: my-app-rb
+  namespace: my-app
+subjects:
+- kind: ServiceAccount
+  name: my-app-sa
+  namespace: my-app
+- kind: ServiceAccount
+  name: monitoring
+  namespace: monitoring`
+
+func TestCheck(t *testing.T) {
+	deployment := internal.GenerateObj(strDeployment)
+	roleBinding := internal.GenerateObj(strRoleBinding)
+	appMeta := metadata.New(config.Config{ChartName: "chart"})
+	appMeta.Load(deployment)
+	appMeta.Load(roleBinding)
+
+	warnings := Check(appMeta, []*unstructured.Unstructured{deployment, roleBinding})
+	var messages []string
+	for _, w := range warnings {
+		messages = append(messages, w.String())
+	}
+	assert.ElementsMatch(t, []string{
+		`Deployment my-app-web: container web uses image "nginx:latest" without a fixed tag`,
+		`Deployment my-app-web: container web has no resource limits`,
+		`Deployment my-app-web: container sidecar uses image "registry.local:5000/sidecar" without a fixed tag`,
+		`Deployment my-app-web: container sidecar has no resource limits`,
+		`Deployment my-app-web: persistent volume claim my-app-data is shared between replicas, consider StatefulSet with volumeClaimTemplates`,
+		`RoleBinding my-app-rb: subject monitoring references hardcoded namespace monitoring`,
+	}, messages)
+}
+
+func Test_isLatest(t *testing.T) {
+	assert.True(t, isLatest("nginx:latest"))
+	assert.True(t, isLatest("nginx"))
+	assert.True(t, isLatest("localhost:5000/nginx"))
+	assert.False(t, isLatest("nginx:1.25.0"))
+	assert.False(t, isLatest("nginx@sha256:4f5e"))
+}
+
+const strClusterRoles = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: my-app-reader
+rules:
+- apiGroups: [""]
+  resources: ["pods", "services"]
+  verbs: ["get", "list"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: my-app-viewer
+rules:
+- apiGroups: [""]
+  resources: ["services", "pods"]
+  verbs: ["list", "get"]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: my-app-manager
+rules:
+- apiGroups: [""]
+  resources: ["pods", "services"]
+  verbs: ["get", "list"]
+- apiGroups: ["apps"]
+  resources: ["deployments"]
+  verbs: ["update"]`
+
+func TestCheck_DuplicateRules(t *testing.T) {
+	var objs []*unstructured.Unstructured
+	for _, doc := range strings.Split(strClusterRoles, "---\n") {
+		objs = append(objs, internal.GenerateObj(doc))
+	}
+	appMeta := metadata.New(config.Config{ChartName: "chart"})
+	for _, obj := range objs {
+		appMeta.Load(obj)
+	}
+
+	warnings := Check(appMeta, objs)
+	var messages []string
+	for _, w := range warnings {
+		messages = append(messages, w.String())
+	}
+	assert.Equal(t, []string{
+		`ClusterRole my-app-viewer: rules are identical to ClusterRole my-app-reader`,
+		`ClusterRole my-app-manager: rule {"verbs":["get","list"],"apiGroups":[""],"resources":["pods","services"]} duplicates rule of ClusterRole my-app-reader`,
+	}, messages)
+}
+
+const strCrossNamespace = `apiVersion: v1
+kind: Secret
+metadata:
+  name: my-app-db
+  namespace: shared
+data:
+  password: cXdlcnR5
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-app-config
+  namespace: my-app
+data:
+  key: value
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+  namespace: my-app
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25
+        resources:
+          limits:
+            cpu: 100m
+        env:
+        - name: DB_PASSWORD
+          valueFrom:
+            secretKeyRef:
+              name: my-app-db
+              key: password
+        - name: METRICS_URL
+          value: http://prometheus.monitoring.svc:9090
+        - name: API_URL
+          value: http://my-app-api.my-app.svc.cluster.local
+        envFrom:
+        - configMapRef:
+            name: my-app-config`
+
+func TestCheck_CrossNamespaceRefs(t *testing.T) {
+	var objs []*unstructured.Unstructured
+	for _, doc := range strings.Split(strCrossNamespace, "---\n") {
+		objs = append(objs, internal.GenerateObj(doc))
+	}
+	appMeta := metadata.New(config.Config{ChartName: "chart"})
+	for _, obj := range objs {
+		appMeta.Load(obj)
+	}
+
+	warnings := Check(appMeta, objs)
+	var messages []string
+	for _, w := range warnings {
+		messages = append(messages, w.String())
+	}
+	assert.Contains(t, messages, `Deployment my-app-web: references Secret my-app-db in namespace shared, but is in namespace my-app: reference needs manual attention`)
+	assert.Contains(t, messages, `Deployment my-app-web: container web env METRICS_URL references Service prometheus in namespace monitoring: reference needs manual attention`)
+	for _, msg := range messages {
+		assert.NotContains(t, msg, "my-app-config")
+		assert.NotContains(t, msg, "my-app-api")
+	}
+}
@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serviceDNSRe - matches Service DNS name with namespace, e.g. my-svc.my-ns.svc or my-svc.my-ns.svc.cluster.local.
+var serviceDNSRe = regexp.MustCompile(`\b([a-z0-9]([-a-z0-9]*[a-z0-9])?)\.([a-z0-9]([-a-z0-9]*[a-z0-9])?)\.svc\b`)
+
+// namespacedRefs - namespaces of Secrets, ConfigMaps and Services of the input by kind and name.
+type namespacedRefs struct {
+	appNamespace string
+	namespaces   map[string]map[string]string
+}
+
+func newNamespacedRefs(appMeta helmify.AppMetadata, objs []*unstructured.Unstructured) namespacedRefs {
+	res := namespacedRefs{appNamespace: appMeta.Namespace(), namespaces: map[string]map[string]string{}}
+	for _, obj := range objs {
+		switch obj.GetKind() {
+		case "Secret", "ConfigMap", "Service":
+		default:
+			continue
+		}
+		if obj.GetAPIVersion() != "v1" {
+			continue
+		}
+		if res.namespaces[obj.GetKind()] == nil {
+			res.namespaces[obj.GetKind()] = map[string]string{}
+		}
+		res.namespaces[obj.GetKind()][obj.GetName()] = res.namespace(obj.GetNamespace())
+	}
+	return res
+}
+
+// namespace - returns given object namespace or the app namespace if not set.
+func (r namespacedRefs) namespace(ns string) string {
+	if ns == "" {
+		return r.appNamespace
+	}
+	return ns
+}
+
+// check - returns warnings for references of pod template of given object to Secrets and ConfigMaps of the input
+// in other namespaces and for Service DNS names with other namespaces in container env values. All objects are
+// moved to the release namespace, so such references can't be kept by the chart.
+func (r namespacedRefs) check(obj *unstructured.Unstructured) []string {
+	path, ok := podSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil
+	}
+	podSpecMap, ok, _ := unstructured.NestedMap(obj.Object, path...)
+	if !ok {
+		return nil
+	}
+	podSpec := corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(podSpecMap, &podSpec); err != nil {
+		return nil
+	}
+	ns := r.namespace(obj.GetNamespace())
+	var res []string
+	ref := func(kind, name string) {
+		if refNs, ok := r.namespaces[kind][name]; ok && refNs != ns {
+			res = append(res, fmt.Sprintf("references %s %s in namespace %s, but is in namespace %s: reference needs manual attention", kind, name, refNs, ns))
+		}
+	}
+	for _, s := range podSpec.ImagePullSecrets {
+		ref("Secret", s.Name)
+	}
+	for _, v := range podSpec.Volumes {
+		if v.Secret != nil {
+			ref("Secret", v.Secret.SecretName)
+		}
+		if v.ConfigMap != nil {
+			ref("ConfigMap", v.ConfigMap.Name)
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil {
+					ref("Secret", source.Secret.Name)
+				}
+				if source.ConfigMap != nil {
+					ref("ConfigMap", source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+	containers := append(append([]corev1.Container{}, podSpec.InitContainers...), podSpec.Containers...)
+	for _, c := range containers {
+		for _, e := range c.EnvFrom {
+			if e.SecretRef != nil {
+				ref("Secret", e.SecretRef.Name)
+			}
+			if e.ConfigMapRef != nil {
+				ref("ConfigMap", e.ConfigMapRef.Name)
+			}
+		}
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				ref("Secret", e.ValueFrom.SecretKeyRef.Name)
+			}
+			if e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil {
+				ref("ConfigMap", e.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			for _, m := range serviceDNSRe.FindAllStringSubmatch(e.Value, -1) {
+				if m[3] != ns {
+					res = append(res, fmt.Sprintf("container %s env %s references Service %s in namespace %s: reference needs manual attention", c.Name, e.Name, m[1], m[3]))
+				}
+			}
+		}
+	}
+	return res
+}
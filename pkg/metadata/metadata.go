@@ -3,62 +3,185 @@ package metadata
 import (
 	"fmt"
 	"github.com/arttor/helmify/pkg/config"
+	"hash/fnv"
+	"regexp"
 	"strings"
 
 	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const nameTeml = `{{ include "%s.fullname" . }}-%s`
 
+const releaseNameTeml = `{{ .Release.Name }}-%s`
+
 var nsGVK = schema.GroupVersionKind{
 	Group:   "",
 	Version: "v1",
 	Kind:    "Namespace",
 }
 
+var svcGVK = schema.GroupVersionKind{
+	Group:   "",
+	Version: "v1",
+	Kind:    "Service",
+}
+
 var crdGVK = schema.GroupVersionKind{
 	Group:   "apiextensions.k8s.io",
 	Version: "v1",
 	Kind:    "CustomResourceDefinition",
 }
 
+// hashSuffixRe - matches content hash suffix appended by kustomize configMapGenerator and secretGenerator, e.g.
+// my-config-7fmb6gk9t4.
+var hashSuffixRe = regexp.MustCompile(`-[2456789bcdfghkmt]{8,10}$`)
+
+// clusterScopedKinds - kinds of cluster-scoped resources which names can be templated by group.
+var clusterScopedKinds = map[string][]string{
+	"rbac.authorization.k8s.io":    {"ClusterRole", "ClusterRoleBinding"},
+	"admissionregistration.k8s.io": {"ValidatingWebhookConfiguration", "MutatingWebhookConfiguration", "ValidatingAdmissionPolicy", "ValidatingAdmissionPolicyBinding"},
+	"storage.k8s.io":               {"StorageClass", "CSIDriver", "VolumeAttachment"},
+	"":                             {"PersistentVolume"},
+	"policy":                       {"PodSecurityPolicy"},
+}
+
 func New(conf config.Config) *Service {
-	return &Service{names: make(map[string]struct{}), conf: conf}
+	return &Service{
+		names:   make(map[string]schema.GroupVersionKind),
+		schemas: make(map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps),
+		ports:   make(map[string][]corev1.ServicePort),
+		conf:    conf,
+	}
 }
 
 type Service struct {
 	commonPrefix string
+	prefixNames  int
+	// commonSuffix - common name suffix starting with a separator, e.g. kustomize nameSuffix. Detected only for
+	// multiple distinct names if enabled in config.
+	commonSuffix string
+	suffixNames  int
 	namespace    string
-	names        map[string]struct{}
-	conf         config.Config
+	// names - loaded object names with GroupVersionKind of the first object with the name.
+	names map[string]schema.GroupVersionKind
+	// schemas - OpenAPI schemas of custom resources defined by loaded CRDs.
+	schemas map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps
+	// ports - ports of loaded Services by Service name.
+	ports map[string][]corev1.ServicePort
+	conf  config.Config
 }
 
 func (a *Service) Config() config.Config {
 	return a.conf
 }
 
-// TrimName - tries to trim app common prefix for object name if detected.
+// TrimName - trims prefixes explicitly set in config from object name one by one. If none of them matched - tries
+// to trim app common prefix for object name if detected. Common suffix of all names, e.g. added by kustomize
+// nameSuffix, is trimmed as well if enabled in config.
 // If no common prefix - returns name as it is.
 // It is better to trim common prefix because Helm also adds release name as common prefix.
+// For loaded objects, short hash of object GroupVersionKind and name is used when explicit prefixes trim the whole name
+// or suffixed when the trimmed name is the same as of another loaded object. Thus, names and filenames never collide.
 func (a *Service) TrimName(objName string) string {
+	trimmed := a.trim(objName)
+	gvk, loaded := a.names[objName]
+	if !loaded {
+		if trimmed == "" {
+			return objName
+		}
+		return trimmed
+	}
+	if trimmed == "" {
+		return strings.ToLower(gvk.Kind) + "-" + nameHash(gvk, objName)
+	}
+	for name := range a.names {
+		if name != objName && a.trim(name) == trimmed {
+			return trimmed + "-" + nameHash(gvk, objName)
+		}
+	}
+	return trimmed
+}
+
+// trim - returns object name without prefixes. Returns empty string if explicit prefixes trimmed the whole name.
+func (a *Service) trim(objName string) string {
+	objName = a.stripHashSuffix(objName)
+	if trimmed, ok := a.trimRemovePrefixes(objName); ok {
+		return a.trimSuffix(trimmed)
+	}
 	trimmed := strings.TrimPrefix(objName, a.commonPrefix)
 	trimmed = strings.TrimLeft(trimmed, "-./_ ")
 	if trimmed == "" {
+		// detected common prefix is the whole name.
 		return objName
 	}
+	return a.trimSuffix(trimmed)
+}
+
+// stripHashSuffix - removes kustomize generator hash suffix from names of loaded ConfigMaps and Secrets if enabled
+// in config.
+func (a *Service) stripHashSuffix(objName string) string {
+	if !a.conf.StripHashSuffix {
+		return objName
+	}
+	if gvk, ok := a.names[objName]; !ok || gvk.Group != "" || (gvk.Kind != "ConfigMap" && gvk.Kind != "Secret") {
+		return objName
+	}
+	return hashSuffixRe.ReplaceAllString(objName, "")
+}
+
+// trimSuffix - returns name without detected common suffix unless the suffix is the whole name.
+func (a *Service) trimSuffix(name string) string {
+	if a.suffixNames < 2 || a.commonSuffix == "" {
+		return name
+	}
+	trimmed := strings.TrimRight(strings.TrimSuffix(name, a.commonSuffix), "-./_ ")
+	if trimmed == "" {
+		return name
+	}
 	return trimmed
 }
 
+func (a *Service) trimRemovePrefixes(objName string) (string, bool) {
+	trimmed, matched := objName, false
+	for _, prefix := range a.conf.RemovePrefixes {
+		if prefix == "" || !strings.HasPrefix(trimmed, prefix) {
+			continue
+		}
+		trimmed = strings.TrimLeft(strings.TrimPrefix(trimmed, prefix), "-./_ ")
+		matched = true
+	}
+	return trimmed, matched
+}
+
+// nameHash - returns short deterministic hash of object GroupVersionKind and name.
+func nameHash(gvk schema.GroupVersionKind, name string) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(gvk.String() + "/" + name))
+	return fmt.Sprintf("%08x", h.Sum32())
+}
+
 var _ helmify.AppMetadata = &Service{}
 
 // Load processed objects one-by-one before actual processing to define app namespace, name common prefix and
 // other app meta information.
 func (a *Service) Load(obj *unstructured.Unstructured) {
-	a.names[obj.GetName()] = struct{}{}
-	a.commonPrefix = detectCommonPrefix(obj, a.commonPrefix)
+	if _, exists := a.names[obj.GetName()]; !exists {
+		a.names[obj.GetName()] = obj.GroupVersionKind()
+		a.detectCommonSuffix(obj)
+	}
+	a.detectCommonPrefix(obj)
+	if obj.GroupVersionKind() == crdGVK {
+		a.loadSchemas(obj)
+	}
+	if obj.GroupVersionKind() == svcGVK {
+		a.loadPorts(obj)
+	}
 	objNs := extractAppNamespace(obj)
 	if objNs == "" {
 		return
@@ -69,6 +192,50 @@ func (a *Service) Load(obj *unstructured.Unstructured) {
 	a.namespace = objNs
 }
 
+// loadSchemas - stores OpenAPI schemas of all served versions of given CRD.
+func (a *Service) loadSchemas(obj *unstructured.Unstructured) {
+	crd := apiextensionsv1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &crd); err != nil {
+		logrus.WithError(err).WithField("crd", obj.GetName()).Debug("unable to read CRD schema")
+		return
+	}
+	if a.schemas == nil {
+		a.schemas = make(map[schema.GroupVersionKind]*apiextensionsv1.JSONSchemaProps)
+	}
+	for _, version := range crd.Spec.Versions {
+		if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+			continue
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: version.Name, Kind: crd.Spec.Names.Kind}
+		a.schemas[gvk] = version.Schema.OpenAPIV3Schema
+	}
+}
+
+// CRDSchema returns OpenAPI schema of custom resource defined by a loaded CRD.
+func (a *Service) CRDSchema(gvk schema.GroupVersionKind) (*apiextensionsv1.JSONSchemaProps, bool) {
+	s, ok := a.schemas[gvk]
+	return s, ok
+}
+
+// loadPorts - stores ports of given Service.
+func (a *Service) loadPorts(obj *unstructured.Unstructured) {
+	svc := corev1.Service{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &svc); err != nil {
+		logrus.WithError(err).WithField("service", obj.GetName()).Debug("unable to read service ports")
+		return
+	}
+	if a.ports == nil {
+		a.ports = make(map[string][]corev1.ServicePort)
+	}
+	a.ports[obj.GetName()] = svc.Spec.Ports
+}
+
+// ServicePorts returns ports of a loaded Service.
+func (a *Service) ServicePorts(name string) ([]corev1.ServicePort, bool) {
+	p, ok := a.ports[name]
+	return p, ok
+}
+
 // Namespace returns detected app namespace.
 func (a *Service) Namespace() string {
 	return a.namespace
@@ -87,10 +254,23 @@ func (a *Service) TemplatedName(name string) string {
 		// template only app objects
 		return name
 	}
+	gvk := a.names[name]
 	name = a.TrimName(name)
+	if a.conf.ReleasePrefixClusterScoped && isClusterScoped(gvk) {
+		return fmt.Sprintf(releaseNameTeml, name)
+	}
 	return fmt.Sprintf(nameTeml, a.conf.ChartName, name)
 }
 
+func isClusterScoped(gvk schema.GroupVersionKind) bool {
+	for _, kind := range clusterScopedKinds[gvk.Group] {
+		if kind == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Service) TemplatedString(str string) string {
 	name := a.TrimName(str)
 	return fmt.Sprintf(nameTeml, a.conf.ChartName, name)
@@ -103,14 +283,46 @@ func extractAppNamespace(obj *unstructured.Unstructured) string {
 	return obj.GetNamespace()
 }
 
-func detectCommonPrefix(obj *unstructured.Unstructured, prevName string) string {
+// detectCommonPrefix - updates common prefix of loaded names. Once detected as empty, the prefix stays empty.
+func (a *Service) detectCommonPrefix(obj *unstructured.Unstructured) {
 	if obj.GroupVersionKind() == crdGVK || obj.GroupVersionKind() == nsGVK {
-		return prevName
+		return
 	}
-	if prevName == "" {
-		return obj.GetName()
+	a.prefixNames++
+	if a.prefixNames == 1 {
+		a.commonPrefix = obj.GetName()
+		return
+	}
+	a.commonPrefix = commonPrefix(obj.GetName(), a.commonPrefix)
+}
+
+// detectCommonSuffix - updates common suffix of loaded distinct names. Suffix is cut to start with a separator, so
+// only whole name parts are trimmed, e.g. '-v2' for 'web-v2' and 'db-v2'.
+func (a *Service) detectCommonSuffix(obj *unstructured.Unstructured) {
+	if !a.conf.TrimSuffix || obj.GroupVersionKind() == crdGVK || obj.GroupVersionKind() == nsGVK {
+		return
+	}
+	a.suffixNames++
+	if a.suffixNames == 1 {
+		a.commonSuffix = obj.GetName()
+		return
+	}
+	suffix := commonSuffix(obj.GetName(), a.commonSuffix)
+	if i := strings.IndexAny(suffix, "-._"); i >= 0 {
+		a.commonSuffix = suffix[i:]
+		return
+	}
+	a.commonSuffix = ""
+}
+
+func commonSuffix(one, two string) string {
+	runes1 := []rune(one)
+	runes2 := []rune(two)
+	i := 0
+	for i < len(runes1) && i < len(runes2) && runes1[len(runes1)-1-i] == runes2[len(runes2)-1-i] {
+		i++
 	}
-	return commonPrefix(obj.GetName(), prevName)
+	return string(runes1[len(runes1)-i:])
 }
 
 func commonPrefix(one, two string) string {
@@ -103,6 +103,116 @@ func Test_Service(t *testing.T) {
 		assert.Equal(t, "abc", testSvc.TrimName("abc"))
 		assert.Equal(t, "service", testSvc.TrimName("service"))
 	})
+	t.Run("trim explicit prefixes", func(t *testing.T) {
+		testSvc := New(config.Config{RemovePrefixes: []string{"myoperator-", "legacy-"}})
+		testSvc.Load(createRes("myoperator-manager", "ns"))
+		testSvc.Load(createRes("legacy-webhook", "ns"))
+		testSvc.Load(createRes("myoperator-legacy-cache", "ns"))
+		testSvc.Load(createRes("other", "ns"))
+
+		assert.Equal(t, "manager", testSvc.TrimName("myoperator-manager"))
+		assert.Equal(t, "webhook", testSvc.TrimName("legacy-webhook"))
+		assert.Equal(t, "cache", testSvc.TrimName("myoperator-legacy-cache"))
+		assert.Equal(t, "other", testSvc.TrimName("other"))
+	})
+	t.Run("trim explicit prefixes: common prefix fallback", func(t *testing.T) {
+		testSvc := New(config.Config{RemovePrefixes: []string{"legacy-"}})
+		testSvc.Load(createRes("abc-name1", "ns"))
+		testSvc.Load(createRes("abc-name2", "ns"))
+		testSvc.Load(createRes("abc-service", "ns"))
+
+		assert.Equal(t, "name1", testSvc.TrimName("abc-name1"))
+		assert.Equal(t, "name2", testSvc.TrimName("abc-name2"))
+	})
+	t.Run("trim explicit prefixes: whole name", func(t *testing.T) {
+		testSvc := New(config.Config{RemovePrefixes: []string{"my-app"}})
+		testSvc.Load(createRes("my-app", "ns"))
+		testSvc.Load(createRes("my-app-web", "ns"))
+
+		trimmed := testSvc.TrimName("my-app")
+		assert.Regexp(t, `^secret-[0-9a-f]{8}$`, trimmed)
+		assert.Equal(t, trimmed, testSvc.TrimName("my-app"), "deterministic")
+		assert.Equal(t, "web", testSvc.TrimName("my-app-web"))
+		assert.Equal(t, "my-app-", testSvc.TrimName("my-app-"), "unknown names are not hashed")
+	})
+	t.Run("trim duplicate names", func(t *testing.T) {
+		testSvc := New(config.Config{RemovePrefixes: []string{"first-", "second-"}})
+		testSvc.Load(createRes("first-web", "ns"))
+		testSvc.Load(createRes("second-web", "ns"))
+		testSvc.Load(createRes("first-db", "ns"))
+
+		first, second := testSvc.TrimName("first-web"), testSvc.TrimName("second-web")
+		assert.Regexp(t, `^web-[0-9a-f]{8}$`, first)
+		assert.Regexp(t, `^web-[0-9a-f]{8}$`, second)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, "db", testSvc.TrimName("first-db"))
+	})
+	t.Run("trim kustomize prefix", func(t *testing.T) {
+		testSvc := New(config.Config{})
+		testSvc.Load(createRes("myapp-web", "ns"))
+		testSvc.Load(createRes("myapp-db", "ns"))
+		testSvc.Load(createRes("myapp-config", "ns"))
+
+		assert.Equal(t, "web", testSvc.TrimName("myapp-web"))
+		assert.Equal(t, "db", testSvc.TrimName("myapp-db"))
+		assert.Equal(t, "config", testSvc.TrimName("myapp-config"))
+	})
+	t.Run("trim kustomize suffix", func(t *testing.T) {
+		testSvc := New(config.Config{TrimSuffix: true})
+		testSvc.Load(createRes("myapp-web-v2", "ns"))
+		testSvc.Load(createRes("myapp-db-v2", "ns"))
+		testSvc.Load(createRes("myapp-b-v2", "ns"))
+
+		assert.Equal(t, "web", testSvc.TrimName("myapp-web-v2"))
+		assert.Equal(t, "db", testSvc.TrimName("myapp-db-v2"))
+		assert.Equal(t, "b", testSvc.TrimName("myapp-b-v2"))
+	})
+	t.Run("trim kustomize nameSuffix", func(t *testing.T) {
+		testSvc := New(config.Config{TrimSuffix: true})
+		testSvc.Load(createRes("web-staging", "ns"))
+		testSvc.Load(createRes("db-staging", "ns"))
+		testSvc.Load(createRes("cache-staging", "ns"))
+
+		assert.Equal(t, "web", testSvc.TrimName("web-staging"))
+		assert.Equal(t, "db", testSvc.TrimName("db-staging"))
+		assert.Equal(t, "cache", testSvc.TrimName("cache-staging"))
+	})
+	t.Run("trim no suffix if disabled", func(t *testing.T) {
+		testSvc := New(config.Config{})
+		testSvc.Load(createRes("web-staging", "ns"))
+		testSvc.Load(createRes("db-staging", "ns"))
+
+		assert.Equal(t, "web-staging", testSvc.TrimName("web-staging"))
+		assert.Equal(t, "db-staging", testSvc.TrimName("db-staging"))
+	})
+	t.Run("trim no suffix of single name", func(t *testing.T) {
+		testSvc := New(config.Config{TrimSuffix: true})
+		testSvc.Load(createRes("myapp-web", "ns"))
+		assert.Equal(t, "myapp-web", testSvc.TrimName("myapp-web"))
+	})
+	t.Run("trim no suffix of same names", func(t *testing.T) {
+		for _, trimSuffix := range []bool{false, true} {
+			testSvc := New(config.Config{ChartName: "chart", TrimSuffix: trimSuffix})
+			testSvc.Load(internal.GenerateObj("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: my-app"))
+			testSvc.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: my-app"))
+
+			assert.Equal(t, "my-app", testSvc.TrimName("my-app"))
+			assert.Equal(t, `{{ include "chart.fullname" . }}-my-app`, testSvc.TemplatedName("my-app"))
+		}
+	})
+	t.Run("strip kustomize hash suffix", func(t *testing.T) {
+		testSvc := New(config.Config{ChartName: "chart-name", StripHashSuffix: true})
+		testSvc.Load(createRes("myapp-config-7fmb6gk9t4", "ns"))
+		testSvc.Load(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myapp-env-g2thk5m7f8\n  namespace: ns"))
+		testSvc.Load(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: myapp-settings\n  namespace: ns"))
+		testSvc.Load(internal.GenerateObj("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: myapp-web-bcdfghkm\n  namespace: ns"))
+
+		assert.Equal(t, "config", testSvc.TrimName("myapp-config-7fmb6gk9t4"))
+		assert.Equal(t, "env", testSvc.TrimName("myapp-env-g2thk5m7f8"))
+		assert.Equal(t, `{{ include "chart-name.fullname" . }}-env`, testSvc.TemplatedName("myapp-env-g2thk5m7f8"))
+		assert.Equal(t, "settings", testSvc.TrimName("myapp-settings"))
+		assert.Equal(t, "web-bcdfghkm", testSvc.TrimName("myapp-web-bcdfghkm"), "only ConfigMaps and Secrets")
+	})
 	t.Run("template name", func(t *testing.T) {
 		testSvc := New(config.Config{ChartName: "chart-name"})
 		testSvc.Load(createRes("abc", "ns"))
@@ -115,6 +225,17 @@ func Test_Service(t *testing.T) {
 		assert.Equal(t, "qwe", testSvc.TemplatedName("qwe"))
 		assert.NotEqual(t, "abc", testSvc.TemplatedName("abc"))
 	})
+	t.Run("template name: release prefix for cluster-scoped", func(t *testing.T) {
+		testSvc := New(config.Config{ChartName: "chart-name", ReleasePrefixClusterScoped: true})
+		clusterRole := internal.GenerateObj(`apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: my-app-manager-role`)
+		testSvc.Load(clusterRole)
+		testSvc.Load(createRes("my-app-web", "ns"))
+		assert.Equal(t, `{{ .Release.Name }}-manager-role`, testSvc.TemplatedName("my-app-manager-role"))
+		assert.Equal(t, `{{ include "chart-name.fullname" . }}-web`, testSvc.TemplatedName("my-app-web"))
+	})
 }
 
 func createRes(name, ns string) *unstructured.Unstructured {
@@ -0,0 +1,53 @@
+package oci
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"oras.land/oras-go/pkg/content"
+	"oras.land/oras-go/pkg/oras"
+)
+
+// Walk - pulls OCI artifact by given reference, e.g. ghcr.io/org/manifests:1.0.0, and calls walkFunc for every
+// layer of the artifact in manifest order. Layers are passed as is: plain and archived manifests are decoded by
+// decoder. Registry credentials are taken from the standard docker config, e.g. ~/.docker/config.json.
+func Walk(ctx context.Context, ref string, plainHTTP bool, walkFunc func(name string, r io.Reader)) error {
+	registry, err := content.NewRegistry(content.RegistryOptions{PlainHTTP: plainHTTP})
+	if err != nil {
+		return fmt.Errorf("%w: unable to create registry client", err)
+	}
+	store := content.NewMemory()
+	var manifestData []byte
+	_, err = oras.Copy(ctx, registry, ref, store, "",
+		oras.WithPullEmptyNameAllowed(),
+		oras.WithRootManifest(func(b []byte) { manifestData = b }))
+	if err != nil {
+		return fmt.Errorf("%w: unable to pull %s", err, ref)
+	}
+	manifest := ocispec.Manifest{}
+	if err = json.Unmarshal(manifestData, &manifest); err != nil {
+		return fmt.Errorf("%w: unable to parse manifest of %s", err, ref)
+	}
+	if len(manifest.Layers) == 0 {
+		return fmt.Errorf("no layers found in %s", ref)
+	}
+	for _, layer := range manifest.Layers {
+		_, data, ok := store.Get(layer)
+		if !ok {
+			return fmt.Errorf("layer %s of %s is not pulled", layer.Digest, ref)
+		}
+		name := layer.Annotations[ocispec.AnnotationTitle]
+		logrus.WithFields(logrus.Fields{
+			"Ref":       ref,
+			"Layer":     name,
+			"MediaType": layer.MediaType,
+		}).Debug("read OCI layer")
+		walkFunc(name, bytes.NewReader(data))
+	}
+	return nil
+}
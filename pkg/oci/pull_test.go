@@ -0,0 +1,99 @@
+package oci
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+const manifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-app-config
+data:
+  key: value`
+
+// fakeRegistry - serves single artifact of given layers by its tag over OCI distribution API.
+func fakeRegistry(t *testing.T, repo, tag string, layers map[string]string) *httptest.Server {
+	blobs := map[digest.Digest][]byte{}
+	config := []byte("{}")
+	blobs[digest.FromBytes(config)] = config
+	manifest := ocispec.Manifest{
+		MediaType: ocispec.MediaTypeImageManifest,
+		Config: ocispec.Descriptor{
+			MediaType: "application/vnd.oci.empty.v1+json",
+			Digest:    digest.FromBytes(config),
+			Size:      int64(len(config)),
+		},
+	}
+	manifest.SchemaVersion = 2
+	for name, data := range layers {
+		blobs[digest.FromString(data)] = []byte(data)
+		manifest.Layers = append(manifest.Layers, ocispec.Descriptor{
+			MediaType:   "application/yaml",
+			Digest:      digest.FromString(data),
+			Size:        int64(len(data)),
+			Annotations: map[string]string{ocispec.AnnotationTitle: name},
+		})
+	}
+	manifestData, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	manifestDigest := digest.FromBytes(manifestData)
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/v2/"+repo)
+		switch {
+		case r.URL.Path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case path == "/manifests/"+tag || path == "/manifests/"+manifestDigest.String():
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", manifestDigest.String())
+			w.Header().Set("Content-Length", strconv.Itoa(len(manifestData)))
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(manifestData)
+			}
+		case strings.HasPrefix(path, "/blobs/"):
+			blob, ok := blobs[digest.Digest(strings.TrimPrefix(path, "/blobs/"))]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(blob)))
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(blob)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestWalk(t *testing.T) {
+	t.Setenv("DOCKER_CONFIG", t.TempDir())
+	registry := fakeRegistry(t, "org/manifests", "1.0.0", map[string]string{"config.yaml": manifests})
+	defer registry.Close()
+
+	ref := strings.TrimPrefix(registry.URL, "http://") + "/org/manifests:1.0.0"
+	var names, contents []string
+	err := Walk(context.Background(), ref, true, func(name string, r io.Reader) {
+		data, err := io.ReadAll(r)
+		assert.NoError(t, err)
+		names = append(names, name)
+		contents = append(contents, string(data))
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"config.yaml"}, names)
+	assert.Equal(t, []string{manifests}, contents)
+
+	err = Walk(context.Background(), strings.TrimPrefix(registry.URL, "http://")+"/org/manifests:2.0.0", true, func(string, io.Reader) {})
+	assert.Error(t, err, "missing tag")
+}
@@ -0,0 +1,79 @@
+package processor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+)
+
+// refAnnotations - known annotations with '<namespace>/<name>' reference to another resource as a value.
+var refAnnotations = []string{
+	"cert-manager.io/inject-ca-from",
+	"cert-manager.io/inject-ca-from-secret",
+}
+
+const annotationsIncludeTemplate = `  {{- include "%s.annotations" . | nindent 4 }}`
+
+// annotationsOmitIncludeTemplate - annotations helper include without common annotations set on the object.
+const annotationsOmitIncludeTemplate = `  {{- omit (include "%s.annotations" . | fromYaml) %s | toYaml | nindent 4 }}`
+
+// MarshalAnnotations - returns annotations as yaml with given indent. Known annotations referencing app resources
+// are rewritten to templated release namespace and name.
+// Example: 'my-ns/my-app-serving-cert' -> '{{ .Release.Namespace }}/{{ include "chart.fullname" . }}-serving-cert'.
+// References to resources from other namespaces are left as is.
+func MarshalAnnotations(appMeta helmify.AppMetadata, annotations map[string]string, indent int) (string, error) {
+	var refLines []string
+	for _, key := range refAnnotations {
+		ref, ok := annotations[key]
+		if !ok {
+			continue
+		}
+		ns, name, found := strings.Cut(ref, "/")
+		if !found || name == "" || ns != appMeta.Namespace() {
+			continue
+		}
+		// added as is to prevent yaml marshaller from quoting and wrapping the template
+		refLines = append(refLines, key+": {{ .Release.Namespace }}/"+appMeta.TemplatedString(name))
+		delete(annotations, key)
+	}
+	res := strings.Repeat(" ", indent) + "annotations:"
+	if len(annotations) != 0 {
+		var err error
+		res, err = yamlformat.Marshal(map[string]interface{}{"annotations": annotations}, indent)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, line := range refLines {
+		res += "\n" + strings.Repeat(" ", indent+2) + line
+	}
+	return res, nil
+}
+
+// withCommonAnnotations - appends chart annotations helper include to given metadata annotations block. Common
+// annotations already set on the object are omitted from the include to keep the object ones.
+func withCommonAnnotations(appMeta helmify.AppMetadata, block string, own map[string]string) string {
+	var omit []string
+	for key := range appMeta.Config().CommonAnnotations {
+		if _, ok := own[key]; ok {
+			omit = append(omit, strconv.Quote(key))
+		}
+	}
+	if len(omit) == len(appMeta.Config().CommonAnnotations) {
+		return block
+	}
+	include := fmt.Sprintf(annotationsIncludeTemplate, appMeta.ChartName())
+	if len(omit) != 0 {
+		sort.Strings(omit)
+		include = fmt.Sprintf(annotationsOmitIncludeTemplate, appMeta.ChartName(), strings.Join(omit, " "))
+	}
+	block = strings.TrimRight(block, "\n")
+	if block == "" {
+		block = "  annotations:"
+	}
+	return block + "\n" + include
+}
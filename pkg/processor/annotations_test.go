@@ -0,0 +1,73 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalAnnotations(t *testing.T) {
+	appMeta := metadata.New(config.Config{ChartName: "chart"})
+	appMeta.Load(internal.GenerateObj(`apiVersion: v1
+kind: Secret
+metadata:
+  name: my-app-ca
+  namespace: my-app-system`))
+	appMeta.Load(internal.GenerateObj(`apiVersion: cert-manager.io/v1
+kind: Certificate
+metadata:
+  name: my-app-serving-cert
+  namespace: my-app-system`))
+
+	t.Run("references rewritten", func(t *testing.T) {
+		res, err := MarshalAnnotations(appMeta, map[string]string{
+			"cert-manager.io/inject-ca-from":        "my-app-system/my-app-serving-cert",
+			"cert-manager.io/inject-ca-from-secret": "my-app-system/my-app-ca",
+			"example":                               "xyz",
+		}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, `  annotations:
+    example: xyz
+    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "chart.fullname" . }}-serving-cert
+    cert-manager.io/inject-ca-from-secret: {{ .Release.Namespace }}/{{ include "chart.fullname" . }}-ca`, res)
+	})
+	t.Run("other namespace kept", func(t *testing.T) {
+		res, err := MarshalAnnotations(appMeta, map[string]string{
+			"cert-manager.io/inject-ca-from": "other/serving-cert",
+		}, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, `  annotations:
+    cert-manager.io/inject-ca-from: other/serving-cert`, res)
+	})
+}
+
+func TestProcessObjMeta_CommonAnnotations(t *testing.T) {
+	appMeta := metadata.New(config.Config{
+		ChartName:         "chart",
+		CommonAnnotations: map[string]string{"owner": "payments", "team": "core"},
+	})
+	t.Run("added to object without annotations", func(t *testing.T) {
+		res, err := ProcessObjMeta(appMeta, internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm"))
+		assert.NoError(t, err)
+		assert.Contains(t, res, `  annotations:
+  {{- include "chart.annotations" . | nindent 4 }}`)
+	})
+	t.Run("object annotations kept", func(t *testing.T) {
+		res, err := ProcessObjMeta(appMeta, internal.GenerateObj(
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  annotations:\n    owner: billing\n    example: xyz"))
+		assert.NoError(t, err)
+		assert.Contains(t, res, `  annotations:
+    example: xyz
+    owner: billing
+  {{- omit (include "chart.annotations" . | fromYaml) "owner" | toYaml | nindent 4 }}`)
+	})
+	t.Run("all overridden by object", func(t *testing.T) {
+		res, err := ProcessObjMeta(appMeta, internal.GenerateObj(
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n  annotations:\n    owner: billing\n    team: web"))
+		assert.NoError(t, err)
+		assert.NotContains(t, res, "chart.annotations")
+	})
+}
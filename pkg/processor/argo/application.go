@@ -0,0 +1,97 @@
+package argo
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var applicationGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "Application",
+}
+
+// sourceFields - Application source fields lifted to values.
+var sourceFields = []string{"repoURL", "path", "targetRevision"}
+
+// NewApplication creates processor for Argo CD Application resource.
+func NewApplication() helmify.Processor {
+	return &application{}
+}
+
+type application struct{}
+
+// Process Argo CD Application object into template. Repository, path and revision of spec.source are lifted to
+// values, the rest of the spec is kept as is. Returns false if not capable of processing given resource type.
+func (a application) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != applicationGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get application spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no application spec presented")
+	}
+
+	values := helmify.Values{}
+	for _, field := range sourceFields {
+		val, ok, _ := unstructured.NestedString(specMap, "source", field)
+		if !ok {
+			continue
+		}
+		templated, err := values.Add(val, nameCamel, "source", field)
+		if err != nil {
+			return true, nil, err
+		}
+		err = unstructured.SetNestedField(specMap, templated, "source", field)
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to template application source %s", err, field)
+		}
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + spec),
+		values: values,
+	}, nil
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
@@ -0,0 +1,64 @@
+package argo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strApplication = `apiVersion: argoproj.io/v1alpha1
+kind: Application
+metadata:
+  name: guestbook
+  namespace: argocd
+spec:
+  project: default
+  source:
+    repoURL: https://github.com/argoproj/argocd-example-apps.git
+    targetRevision: HEAD
+    path: guestbook
+  destination:
+    server: https://kubernetes.default.svc
+    namespace: guestbook`
+
+func Test_application_Process(t *testing.T) {
+	var testInstance application
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strApplication)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, helmify.Values{
+			"guestbook": map[string]interface{}{
+				"source": map[string]interface{}{
+					"repoURL":        "https://github.com/argoproj/argocd-example-apps.git",
+					"targetRevision": "HEAD",
+					"path":           "guestbook",
+				},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `spec:
+  destination:
+    namespace: guestbook
+    server: https://kubernetes.default.svc
+  project: default
+  source:
+    path: {{ .Values.guestbook.source.path | quote }}
+    repoURL: {{ .Values.guestbook.source.repoURL | quote }}
+    targetRevision: {{ .Values.guestbook.source.targetRevision | quote }}`)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
@@ -0,0 +1,255 @@
+package argo
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/pod"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var rolloutGVK = schema.GroupVersionKind{
+	Group:   "argoproj.io",
+	Version: "v1alpha1",
+	Kind:    "Rollout",
+}
+
+var rolloutTempl, _ = template.New("rollout").Parse(
+	`{{- .Meta }}
+spec:
+{{- if .Replicas }}
+{{ .Replicas }}
+{{- end }}
+{{- if .Selector }}
+  selector:
+{{ .Selector }}
+{{- end }}
+{{- if .Spec }}
+  template:
+    metadata:
+      labels:
+{{ .PodLabels }}
+{{- .PodAnnotations }}
+    spec:
+{{ .Spec }}
+{{- end }}
+{{- if .Rest }}
+{{ .Rest }}
+{{- end }}`)
+
+const selectorTempl = `%[1]s
+{{- include "%[2]s.selectorLabels" . | nindent 6 }}
+%[3]s`
+
+// strategyServices - paths to names of Services referenced by Rollout strategy.
+var strategyServices = [][]string{
+	{"canary", "canaryService"},
+	{"canary", "stableService"},
+	{"blueGreen", "activeService"},
+	{"blueGreen", "previewService"},
+}
+
+// NewRollout creates processor for Argo Rollouts Rollout resource.
+func NewRollout() helmify.Processor {
+	return &rollout{}
+}
+
+type rollout struct{}
+
+// Process Argo Rollout object into template the same way as k8s Deployment. Strategy is kept as is except names of
+// referenced Services. Rollouts referencing a Deployment with workloadRef have no pod template to process. Returns
+// false if not capable of processing given resource type.
+func (r rollout) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != rolloutGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get rollout spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no rollout spec presented")
+	}
+	values := helmify.Values{}
+
+	var replicas string
+	if r, ok, _ := unstructured.NestedInt64(specMap, "replicas"); ok {
+		replicasTpl, err := values.Add(r, nameCamel, "replicas")
+		if err != nil {
+			return true, nil, err
+		}
+		replicas = "  replicas: " + replicasTpl
+	}
+	delete(specMap, "replicas")
+
+	for _, path := range strategyServices {
+		if svc, ok, _ := unstructured.NestedString(specMap, append([]string{"strategy"}, path...)...); ok && svc != "" {
+			err = unstructured.SetNestedField(specMap, appMeta.TemplatedName(svc), append([]string{"strategy"}, path...)...)
+			if err != nil {
+				return true, nil, fmt.Errorf("%w: unable to template rollout strategy service", err)
+			}
+		}
+	}
+	if ref, ok, _ := unstructured.NestedString(specMap, "workloadRef", "name"); ok {
+		err = unstructured.SetNestedField(specMap, appMeta.TemplatedName(ref), "workloadRef", "name")
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to template rollout workloadRef", err)
+		}
+	}
+
+	templateMap, hasTemplate, err := unstructured.NestedMap(specMap, "template")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get rollout pod template", err)
+	}
+	delete(specMap, "template")
+	selectorMap, hasSelector, err := unstructured.NestedMap(specMap, "selector")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get rollout selector", err)
+	}
+	delete(specMap, "selector")
+
+	var selector string
+	if hasTemplate || hasSelector {
+		selector, err = rolloutSelector(appMeta, selectorMap)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+
+	var podLabels, podAnnotations, spec string
+	if hasTemplate {
+		podTemplate := corev1.PodTemplateSpec{}
+		err = runtime.DefaultUnstructuredConverter.FromUnstructured(templateMap, &podTemplate)
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to cast to pod template", err)
+		}
+		if len(podTemplate.Labels) != 0 {
+			podLabels, err = yamlformat.Marshal(podTemplate.Labels, 8)
+			if err != nil {
+				return true, nil, err
+			}
+			podLabels += "\n"
+		}
+		podLabels += fmt.Sprintf(`      {{- include "%s.selectorLabels" . | nindent 8 }}`, appMeta.ChartName())
+		if len(podTemplate.Annotations) != 0 {
+			podAnnotations, err = yamlformat.Marshal(map[string]interface{}{"annotations": podTemplate.Annotations}, 6)
+			if err != nil {
+				return true, nil, err
+			}
+			podAnnotations = "\n" + podAnnotations
+		}
+
+		if err := pod.ReportDroppedFields(appMeta, obj, podTemplate.Spec, "spec", "template", "spec"); err != nil {
+			return true, nil, err
+		}
+		podSpecMap, podValues, err := pod.ProcessSpec(nameCamel, appMeta, podTemplate.Spec)
+		if err != nil {
+			return true, nil, err
+		}
+		err = pod.ProcessInitContainerRestartPolicy(nameCamel, appMeta, podSpecMap, &podValues, templateMap, "spec")
+		if err != nil {
+			return true, nil, err
+		}
+		err = values.Merge(podValues)
+		if err != nil {
+			return true, nil, err
+		}
+		spec, err = yamlformat.MarshalTemplated(podSpecMap, 6)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+
+	var rest string
+	if len(specMap) != 0 {
+		rest, err = yamlformat.Marshal(specMap, 2)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+
+	return true, &rolloutResult{
+		name: name + ".yaml",
+		data: rolloutData{
+			Meta:           meta,
+			Replicas:       replicas,
+			Selector:       selector,
+			PodLabels:      podLabels,
+			PodAnnotations: podAnnotations,
+			Spec:           spec,
+			Rest:           rest,
+		},
+		values: values,
+	}, nil
+}
+
+// rolloutSelector - returns label selector of Rollout extended with chart selector labels.
+func rolloutSelector(appMeta helmify.AppMetadata, selectorMap map[string]interface{}) (string, error) {
+	labelSelector := metav1.LabelSelector{}
+	err := runtime.DefaultUnstructuredConverter.FromUnstructured(selectorMap, &labelSelector)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to cast to label selector", err)
+	}
+	matchLabels := "matchLabels:"
+	if len(labelSelector.MatchLabels) != 0 {
+		matchLabels, err = yamlformat.Marshal(map[string]interface{}{"matchLabels": labelSelector.MatchLabels}, 0)
+		if err != nil {
+			return "", err
+		}
+	}
+	matchExpr := ""
+	if labelSelector.MatchExpressions != nil {
+		matchExpr, err = yamlformat.Marshal(map[string]interface{}{"matchExpressions": labelSelector.MatchExpressions}, 0)
+		if err != nil {
+			return "", err
+		}
+	}
+	selector := fmt.Sprintf(selectorTempl, matchLabels, appMeta.ChartName(), matchExpr)
+	selector = strings.Trim(selector, " \n")
+	return string(yamlformat.Indent([]byte(selector), 4)), nil
+}
+
+type rolloutData struct {
+	Meta           string
+	Replicas       string
+	Selector       string
+	PodLabels      string
+	PodAnnotations string
+	Spec           string
+	Rest           string
+}
+
+type rolloutResult struct {
+	name   string
+	data   rolloutData
+	values helmify.Values
+}
+
+func (r *rolloutResult) Filename() string {
+	return r.name
+}
+
+func (r *rolloutResult) Values() helmify.Values {
+	return r.values
+}
+
+func (r *rolloutResult) Write(writer io.Writer) error {
+	return rolloutTempl.Execute(writer, r.data)
+}
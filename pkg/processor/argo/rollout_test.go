@@ -0,0 +1,121 @@
+package argo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strRollout = `apiVersion: argoproj.io/v1alpha1
+kind: Rollout
+metadata:
+  name: myapp-web
+  namespace: myapp
+spec:
+  replicas: 5
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25.0
+  strategy:
+    canary:
+      canaryService: myapp-canary
+      stableService: myapp-stable
+      steps:
+      - setWeight: 20
+      - pause: {}
+      - setWeight: 50
+      - pause:
+          duration: 10m`
+
+const strRolloutWorkloadRef = `apiVersion: argoproj.io/v1alpha1
+kind: Rollout
+metadata:
+  name: myapp-api
+  namespace: myapp
+spec:
+  workloadRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: myapp-api
+  strategy:
+    blueGreen:
+      activeService: myapp-api`
+
+func Test_rollout_Process(t *testing.T) {
+	var testInstance rollout
+
+	t.Run("canary", func(t *testing.T) {
+		obj := internal.GenerateObj(strRollout)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-canary\n  namespace: myapp"))
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-stable\n  namespace: myapp"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, "web.yaml", tmpl.Filename())
+		assert.Equal(t, int64(5), tmpl.Values()["web"].(map[string]interface{})["replicas"])
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `spec:
+  replicas: {{ .Values.web.replicas }}
+  selector:
+    matchLabels:
+      app: web
+    {{- include "chart.selectorLabels" . | nindent 6 }}
+  template:
+    metadata:
+      labels:
+        app: web
+      {{- include "chart.selectorLabels" . | nindent 8 }}
+    spec:
+      containers:`)
+		assert.Contains(t, buf.String(), "image: {{ .Values.web.web.image.repository }}")
+		assert.Contains(t, buf.String(), `  strategy:
+    canary:
+      canaryService: '{{ include "chart.fullname" . }}-canary'
+      stableService: '{{ include "chart.fullname" . }}-stable'
+      steps:
+      - setWeight: 20
+      - pause: {}
+      - setWeight: 50
+      - pause:
+          duration: 10m`)
+	})
+	t.Run("workloadRef", func(t *testing.T) {
+		obj := internal.GenerateObj(strRolloutWorkloadRef)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(strRollout))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.NotContains(t, buf.String(), "template:")
+		assert.NotContains(t, buf.String(), "selector:")
+		assert.Contains(t, buf.String(), `    blueGreen:
+      activeService: '{{ include "chart.fullname" . }}-api'`)
+		assert.Contains(t, buf.String(), `    name: '{{ include "chart.fullname" . }}-api'`)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
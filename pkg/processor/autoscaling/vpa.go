@@ -0,0 +1,109 @@
+package autoscaling
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// vpaGuard - wraps VerticalPodAutoscaler to be installed only if .Values.vpa.enabled is true.
+const vpaGuard = `{{- if .Values.vpa.enabled }}
+%s
+{{- end }}`
+
+var vpaGVK = schema.GroupVersionKind{
+	Group:   "autoscaling.k8s.io",
+	Version: "v1",
+	Kind:    "VerticalPodAutoscaler",
+}
+
+// NewVerticalPodAutoscaler creates processor for VerticalPodAutoscaler resource.
+func NewVerticalPodAutoscaler() helmify.Processor {
+	return &vpa{}
+}
+
+type vpa struct{}
+
+// Process VerticalPodAutoscaler object into template. Returns false if not capable of processing given resource type.
+func (v vpa) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != vpaGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get vpa spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no vpa spec presented")
+	}
+	values := helmify.Values{
+		"vpa": map[string]interface{}{"enabled": true},
+	}
+
+	if target, ok, _ := unstructured.NestedString(specMap, "targetRef", "name"); ok {
+		err = unstructured.SetNestedField(specMap, appMeta.TemplatedName(target), "targetRef", "name")
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to set vpa targetRef", err)
+		}
+	}
+	if mode, ok, _ := unstructured.NestedString(specMap, "updatePolicy", "updateMode"); ok {
+		tpl, err := values.Add(mode, nameCamel, "updateMode")
+		if err != nil {
+			return true, nil, err
+		}
+		err = unstructured.SetNestedField(specMap, tpl, "updatePolicy", "updateMode")
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to set vpa updateMode", err)
+		}
+	}
+	if policy, ok, _ := unstructured.NestedMap(specMap, "resourcePolicy"); ok {
+		tpl, err := values.AddYaml(policy, 4, true, nameCamel, "resourcePolicy")
+		if err != nil {
+			return true, nil, err
+		}
+		specMap["resourcePolicy"] = tpl
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(fmt.Sprintf(vpaGuard, meta+"\n"+spec)),
+		values: values,
+	}, nil
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
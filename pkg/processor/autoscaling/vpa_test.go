@@ -0,0 +1,93 @@
+package autoscaling
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+  namespace: my-app-system`
+
+const strService = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-app-svc
+  namespace: my-app-system`
+
+const strVPA = `apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: my-app-web-vpa
+  namespace: my-app-system
+spec:
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: my-app-web
+  updatePolicy:
+    updateMode: Auto
+  resourcePolicy:
+    containerPolicies:
+    - containerName: '*'
+      maxAllowed:
+        memory: 1Gi`
+
+func Test_vpa_Process(t *testing.T) {
+	var testInstance vpa
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strVPA)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(internal.GenerateObj(strDeployment))
+		appMeta.Load(internal.GenerateObj(strService))
+		appMeta.Load(obj)
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, helmify.Values{
+			"vpa": map[string]interface{}{"enabled": true},
+			"webVpa": map[string]interface{}{
+				"updateMode": "Auto",
+				"resourcePolicy": map[string]interface{}{
+					"containerPolicies": []interface{}{
+						map[string]interface{}{"containerName": "*", "maxAllowed": map[string]interface{}{"memory": "1Gi"}},
+					},
+				},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Equal(t, `{{- if .Values.vpa.enabled }}
+apiVersion: autoscaling.k8s.io/v1
+kind: VerticalPodAutoscaler
+metadata:
+  name: {{ include "chart.fullname" . }}-web-vpa
+  labels:
+  {{- include "chart.labels" . | nindent 4 }}
+spec:
+  resourcePolicy: {{ .Values.webVpa.resourcePolicy | toYaml | nindent 4 }}
+  targetRef:
+    apiVersion: apps/v1
+    kind: Deployment
+    name: {{ include "chart.fullname" . }}-web
+  updatePolicy:
+    updateMode: {{ .Values.webVpa.updateMode | quote }}
+{{- end }}`, buf.String())
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/arttor/helmify/pkg/format"
 	"io"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -11,7 +12,7 @@ import (
 
 	"github.com/arttor/helmify/pkg/helmify"
 	yamlformat "github.com/arttor/helmify/pkg/yaml"
-	"github.com/sirupsen/logrus"
+	"github.com/iancoleman/strcase"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -28,6 +29,18 @@ var configMapTempl, _ = template.New("configMap").Parse(
 {{ .Data }}
 {{- end }}`)
 
+// valuesAnnotation - annotation of source ConfigMap with comma-separated data keys lifted to values. If set, other
+// keys are kept literal. The annotation is removed from the chart.
+const valuesAnnotation = "helmify.io/values"
+
+// dataBlockTemplate - data of ConfigMap rendered from a single values map with -configmap-data-block.
+const dataBlockTemplate = `data:
+  {{- toYaml .Values.%s.data | nindent 2 }}`
+
+// dashboardLabel - label of ConfigMaps with Grafana dashboards loaded by Grafana sidecar or operator. Dashboard JSON
+// is kept literal instead of lifting it to values.
+const dashboardLabel = "grafana_dashboard"
+
 var configMapGVC = schema.GroupVersionKind{
 	Group:   "",
 	Version: "v1",
@@ -47,6 +60,10 @@ func (d configMap) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 		return false, nil, nil
 	}
 	var meta, immutable, binaryData, data string
+	lifted := liftedKeys(obj)
+	if _, dashboard := obj.GetLabels()[dashboardLabel]; dashboard && lifted == nil {
+		lifted = map[string]bool{}
+	}
 	meta, err := processor.ProcessObjMeta(appMeta, obj)
 	if err != nil {
 		return true, nil, err
@@ -58,7 +75,13 @@ func (d configMap) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 			return true, nil, err
 		}
 	}
-	if field, exists, _ := unstructured.NestedStringMap(obj.Object, "binaryData"); exists {
+	field, exists, err := unstructured.NestedStringMap(obj.Object, "binaryData")
+	if err != nil {
+		if err = processor.ReportLossy(appMeta, obj, "binaryData dropped: "+err.Error()); err != nil {
+			return true, nil, err
+		}
+	}
+	if exists {
 		binaryData, err = yamlformat.Marshal(map[string]interface{}{"binaryData": field}, 0)
 		if err != nil {
 			return true, nil, err
@@ -67,13 +90,32 @@ func (d configMap) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 
 	name := appMeta.TrimName(obj.GetName())
 	var values helmify.Values
-	if field, exists, _ := unstructured.NestedStringMap(obj.Object, "data"); exists {
-		field, values = parseMapData(field, name)
-		data, err = yamlformat.Marshal(map[string]interface{}{"data": field}, 0)
+	field, exists, err = unstructured.NestedStringMap(obj.Object, "data")
+	if err != nil {
+		if err = processor.ReportLossy(appMeta, obj, "data dropped: "+err.Error()); err != nil {
+			return true, nil, err
+		}
+	}
+	if exists && appMeta.Config().ConfigMapDataBlock && lifted == nil {
+		nameCamel := strcase.ToLowerCamel(name)
+		block := make(map[string]interface{}, len(field))
+		for key, value := range field {
+			block[key] = value
+		}
+		values = helmify.Values{}
+		if err = unstructured.SetNestedMap(values, block, nameCamel, "data"); err != nil {
+			return true, nil, fmt.Errorf("%w: unable to set configmap data to values", err)
+		}
+		data = fmt.Sprintf(dataBlockTemplate, nameCamel)
+	} else if exists {
+		field, values, err = parseMapData(appMeta, obj, field, name, lifted)
+		if err != nil {
+			return true, nil, err
+		}
+		data, err = marshalData(field, values, lifted)
 		if err != nil {
 			return true, nil, err
 		}
-		data = strings.ReplaceAll(data, "'", "")
 	}
 
 	return true, &result{
@@ -88,25 +130,100 @@ func (d configMap) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 	}, nil
 }
 
-func parseMapData(data map[string]string, configName string) (map[string]string, helmify.Values) {
+// marshalData - marshals configmap data unquoting templates of lifted keys only. Literal values of keys not
+// listed in valuesAnnotation are escaped and keep their yaml quotes, so they are marshaled key by key.
+func marshalData(data map[string]string, values helmify.Values, lifted map[string]bool) (string, error) {
+	if lifted == nil || len(data) == 0 {
+		res, err := yamlformat.Marshal(map[string]interface{}{"data": data}, 0)
+		if err != nil || len(values) == 0 {
+			return res, err
+		}
+		// literal data may contain quoted braces, e.g. minified json, only templates of lifted keys are unquoted
+		return format.UnquoteTemplates(res), nil
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	res := "data:"
+	for _, key := range keys {
+		entry, err := yamlformat.Marshal(map[string]interface{}{"data": map[string]string{key: data[key]}}, 0)
+		if err != nil {
+			return "", err
+		}
+		entry = strings.TrimPrefix(entry, "data:")
+		if lifted[key] {
+			entry = format.UnquoteTemplates(entry)
+		}
+		res += entry
+	}
+	return res, nil
+}
+
+// liftedKeys - returns data keys listed in valuesAnnotation and removes the annotation from given object.
+// Returns nil if the annotation is not set.
+func liftedKeys(obj *unstructured.Unstructured) map[string]bool {
+	annotations := obj.GetAnnotations()
+	keys, ok := annotations[valuesAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(annotations, valuesAnnotation)
+	obj.SetAnnotations(annotations)
+	res := map[string]bool{}
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			res[key] = true
+		}
+	}
+	return res
+}
+
+// parseMapData - lifts configmap data to values. If lifted is not nil, only given keys are lifted and others are
+// kept literal with template delimiters escaped.
+func parseMapData(appMeta helmify.AppMetadata, obj *unstructured.Unstructured, data map[string]string, configName string, lifted map[string]bool) (map[string]string, helmify.Values, error) {
 	values := helmify.Values{}
 	for key, value := range data {
+		if lifted != nil && !lifted[key] {
+			// literal value is rendered by Helm as is
+			data[key] = format.EscapeTemplates(value)
+			continue
+		}
 		valuesNamePath := []string{configName, key}
 		if strings.HasSuffix(key, ".properties") {
 			// handle properties
 			templated, err := parseProperties(value, valuesNamePath, values)
 			if err != nil {
-				logrus.WithError(err).Errorf("unable to process configmap data: %v", valuesNamePath)
+				if err = processor.ReportLossy(appMeta, obj, fmt.Sprintf("unable to process configmap data %v: %v", valuesNamePath, err)); err != nil {
+					return nil, nil, err
+				}
 				continue
 			}
 			data[key] = templated
 			continue
 		}
+		if appMeta.Config().GroupManagerConfig {
+			templated, grouped, err := groupManagerConfig(value, values)
+			if err != nil {
+				if err = processor.ReportLossy(appMeta, obj, fmt.Sprintf("unable to process manager config %v: %v", valuesNamePath, err)); err != nil {
+					return nil, nil, err
+				}
+				continue
+			}
+			if grouped {
+				data[key] = templated
+				continue
+			}
+		}
 		if strings.Contains(value, "\n") {
+			// multiline value is kept as a single string, so multi-document yaml is preserved as is.
 			value = format.RemoveTrailingWhitespaces(value)
 			templatedVal, err := values.AddYaml(value, 1, false, valuesNamePath...)
 			if err != nil {
-				logrus.WithError(err).Errorf("unable to process multiline configmap data: %v", valuesNamePath)
+				if err = processor.ReportLossy(appMeta, obj, fmt.Sprintf("unable to process multiline configmap data %v: %v", valuesNamePath, err)); err != nil {
+					return nil, nil, err
+				}
 				continue
 			}
 			data[key] = templatedVal
@@ -115,12 +232,14 @@ func parseMapData(data map[string]string, configName string) (map[string]string,
 		// handle plain string
 		templatedVal, err := values.Add(value, valuesNamePath...)
 		if err != nil {
-			logrus.WithError(err).Errorf("unable to process configmap data: %v", valuesNamePath)
+			if err = processor.ReportLossy(appMeta, obj, fmt.Sprintf("unable to process configmap data %v: %v", valuesNamePath, err)); err != nil {
+				return nil, nil, err
+			}
 			continue
 		}
 		data[key] = templatedVal
 	}
-	return data, values
+	return data, values, nil
 }
 
 // func parseProperties(properties string, path []string, values helmify.Values) (string, error) {
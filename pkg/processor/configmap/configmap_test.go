@@ -1,9 +1,15 @@
 package configmap
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
 
 	"github.com/arttor/helmify/internal"
 	"github.com/stretchr/testify/assert"
@@ -22,8 +28,120 @@ data:
     kind: ControllerManagerConfig
     health:
       healthProbeBindAddress: :8081`
+
+	strConfigmapManagerConfig = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-operator-manager-config
+data:
+  controller_manager_config.yaml: |
+    apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+    kind: ControllerManagerConfig
+    health:
+      healthProbeBindAddress: :8081
+    webhook:
+      port: 9443
+    leaderElection:
+      leaderElect: true
+      resourceName: 3a2e09e9.example.com
+    rook:
+      namespace: rook-ceph`
+
+	strConfigmapCustomKey = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-operator-manager-config
+data:
+  config.yaml: |
+    apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+    kind: ControllerManagerConfig
+    leaderElection:
+      leaderElect: true
+  other.yaml: |
+    leaderElection:
+      leaderElect: true`
+
+	strConfigmapSpecialChars = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+data:
+  cron: "@reboot echo hi"
+  mapping: "key: value"
+  "*wildcard": "&x"`
+
+	strConfigmapMultiDoc = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+data:
+  rules.yaml: |
+    groups:
+    - name: first
+    ---
+    groups:
+    - name: second`
+
+	strConfigmapInvalidKeys = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+data:
+  leader-elect: "true"
+  2-workers: "4"`
+
+	strConfigmapEnvInterpolation = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+data:
+  POD_URL: http://$(POD_NAME).svc:8080
+  settings.yaml: |
+    host: $(POD_NAME)`
+
+	strConfigmapValuesAnnotation = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+  annotations:
+    helmify.io/values: "log-level, replicas"
+    team: payments
+data:
+  log-level: debug
+  replicas: "3"
+  endpoint: http://internal:8080
+  tpl: "{{ .Foo }}"
+  app.properties: |
+    my.url=http://internal`
+
+	strConfigmapArray = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-config
+data:
+  hosts:
+  - a
+  - b`
 )
 
+const strConfigmapDashboard = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-app-dashboard
+  labels:
+    grafana_dashboard: "1"
+data:
+  app.json: |
+    {
+      "title": "My app",
+      "panels": [
+        {
+          "targets": [{"expr": "rate(http_requests_total[5m])", "legendFormat": "{{pod}}"}]
+        }
+      ]
+    }
+  minified.json: '{"title":"Minified","templating":{"list":[]}}'`
+
 func Test_configMap_Process(t *testing.T) {
 	var testInstance configMap
 
@@ -39,4 +157,226 @@ func Test_configMap_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, false, processed)
 	})
+	t.Run("special characters", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapSpecialChars)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "cron: {{ .Values.myConfig.cron | quote }}")
+		assert.Contains(t, buf.String(), "mapping: {{ .Values.myConfig.mapping | quote }}")
+		assert.Contains(t, buf.String(), "'*wildcard': {{ .Values.myConfig.wildcard | quote }}")
+		assert.Equal(t, helmify.Values{
+			"myConfig": map[string]interface{}{
+				"cron":     "@reboot echo hi",
+				"mapping":  "key: value",
+				"wildcard": "&x",
+			},
+		}, tmpl.Values())
+	})
+	t.Run("keys not valid as template fields", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapInvalidKeys)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "leader-elect: {{ .Values.myConfig.leaderElect | quote }}")
+		assert.Contains(t, buf.String(), `2-workers: {{ (index .Values "myConfig" "2Workers") | quote }}`)
+	})
+	t.Run("multi-document yaml value", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapMultiDoc)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "rules.yaml: {{ .Values.myConfig.rulesYaml | toYaml | indent 1 }}")
+		assert.Equal(t, helmify.Values{
+			"myConfig": map[string]interface{}{
+				"rulesYaml": "groups:\n- name: first\n---\ngroups:\n- name: second",
+			},
+		}, tmpl.Values())
+	})
+	t.Run("env interpolation preserved", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapEnvInterpolation)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.NotContains(t, buf.String(), "$(")
+		assert.Equal(t, helmify.Values{
+			"myConfig": map[string]interface{}{
+				"podUrl":       "http://$(POD_NAME).svc:8080",
+				"settingsYaml": "host: $(POD_NAME)",
+			},
+		}, tmpl.Values())
+	})
+	t.Run("only annotated keys lifted", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapValuesAnnotation)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"myConfig": map[string]interface{}{
+				"logLevel": "debug",
+				"replicas": "3",
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "log-level: {{ .Values.myConfig.logLevel | quote }}")
+		assert.Contains(t, buf.String(), "replicas: {{ .Values.myConfig.replicas | quote }}")
+		assert.Contains(t, buf.String(), "endpoint: http://internal:8080")
+		assert.Contains(t, buf.String(), `tpl: '{{ "{{" }} .Foo {{ "}}" }}'`, "escaped literal keeps quotes")
+		assert.Contains(t, buf.String(), "app.properties: my.url=http://internal", "properties kept literal")
+		assert.Contains(t, buf.String(), "team: payments")
+		assert.NotContains(t, buf.String(), "helmify.io/values")
+	})
+	t.Run("data block", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmap)
+		appMeta := metadata.New(config.Config{ChartName: "chart", ConfigMapDataBlock: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"myOperatorManagerConfig": map[string]interface{}{
+				"data": map[string]interface{}{
+					"dummyconfigmapkey":              "dummyconfigmapvalue",
+					"controller_manager_config.yaml": "apiVersion: controller-runtime.sigs.k8s.io/v1alpha1\nkind: ControllerManagerConfig\nhealth:\n  healthProbeBindAddress: :8081",
+				},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasSuffix(buf.String(), `
+data:
+  {{- toYaml .Values.myOperatorManagerConfig.data | nindent 2 }}`), buf.String())
+	})
+	t.Run("grafana dashboard kept literal", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapDashboard)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Empty(t, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "grafana_dashboard: \"1\"")
+		assert.Contains(t, buf.String(), `  app.json: |
+    {
+      "title": "My app",`)
+		assert.Contains(t, buf.String(), `"legendFormat": "{{ "{{" }}pod{{ "}}" }}"`)
+		assert.Contains(t, buf.String(), `minified.json: '{"title":"Minified","templating":{"list":[]{{ "}}" }}'`)
+	})
+	t.Run("literal data not representable as block scalar kept", func(t *testing.T) {
+		obj := internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-app-dashboard\n  labels:\n    grafana_dashboard: \"1\"\ndata:\n  app.json: \"{  \\r\\n}\\n\"")
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `app.json: "{  \r\n}\n"`)
+	})
+	t.Run("strict mode fails on array value", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapArray)
+		appMeta := metadata.New(config.Config{ChartName: "chart", Strict: true})
+		appMeta.Load(obj)
+		processed, _, err := testInstance.Process(appMeta, obj)
+		assert.Equal(t, true, processed)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+	})
+	t.Run("array value dropped if not strict", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapArray)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Empty(t, tmpl.Values())
+	})
+	t.Run("manager config grouped", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapManagerConfig)
+		appMeta := metadata.New(config.Config{ChartName: "chart", GroupManagerConfig: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"leaderElection": map[string]interface{}{
+				"leaderElect":  true,
+				"resourceName": "3a2e09e9.example.com",
+			},
+			"health":  map[string]interface{}{"healthProbeBindAddress": ":8081"},
+			"webhook": map[string]interface{}{"port": float64(9443)},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `data:
+  controller_manager_config.yaml: |
+    apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+    health:
+      healthProbeBindAddress: {{ .Values.health.healthProbeBindAddress | quote }}
+    kind: ControllerManagerConfig
+    leaderElection:
+      leaderElect: {{ .Values.leaderElection.leaderElect }}
+      resourceName: {{ .Values.leaderElection.resourceName | quote }}
+    rook:
+      namespace: rook-ceph
+    webhook:
+      port: {{ .Values.webhook.port }}`)
+	})
+	t.Run("manager config detected under any key", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapCustomKey)
+		appMeta := metadata.New(config.Config{ChartName: "chart", GroupManagerConfig: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"leaderElect": true}, tmpl.Values()["leaderElection"])
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `  config.yaml: |
+    apiVersion: controller-runtime.sigs.k8s.io/v1alpha1
+    kind: ControllerManagerConfig
+    leaderElection:
+      leaderElect: {{ .Values.leaderElection.leaderElect }}`)
+		assert.Contains(t, buf.String(), "other.yaml: {{ .Values.myOperatorManagerConfig.otherYaml | toYaml | indent 1 }}", "not a manager config")
+	})
+	t.Run("manager config kept as is if not enabled", func(t *testing.T) {
+		obj := internal.GenerateObj(strConfigmapManagerConfig)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Contains(t, tmpl.Values(), "myOperatorManagerConfig")
+		assert.NotContains(t, tmpl.Values(), "leaderElection")
+	})
+}
+
+func Test_templateLeaves(t *testing.T) {
+	t.Run("go int value handled", func(t *testing.T) {
+		values := helmify.Values{}
+		res, err := templateLeaves(map[string]interface{}{"port": 9443, "weight": float32(0.5)}, values, "webhook")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"port":   "{{ .Values.webhook.port }}",
+			"weight": "{{ .Values.webhook.weight }}",
+		}, res)
+		assert.Equal(t, helmify.Values{"webhook": map[string]interface{}{"port": int64(9443), "weight": 0.5}}, values)
+	})
+	t.Run("unsupported value fails", func(t *testing.T) {
+		_, err := templateLeaves(map[string]interface{}{"ports": []int{9443}}, helmify.Values{}, "webhook")
+		assert.ErrorIs(t, err, helmify.ErrUnsupportedValue)
+	})
 }
@@ -0,0 +1,70 @@
+package configmap
+
+import (
+	"fmt"
+
+	"github.com/arttor/helmify/pkg/format"
+	"github.com/arttor/helmify/pkg/helmify"
+	"sigs.k8s.io/yaml"
+)
+
+const managerConfigKind = "ControllerManagerConfig"
+
+// managerConfigBlocks - sections of controller-runtime ControllerManagerConfig lifted to top level values.
+var managerConfigBlocks = []string{"leaderElection", "metrics", "webhook", "health"}
+
+// groupManagerConfig - templates scalar settings of recognized ControllerManagerConfig sections with top level
+// values, e.g. leaderElection.leaderElect -> .Values.leaderElection.leaderElect. Other content is kept as is.
+// Config is detected by its kind, so any data key works, e.g. controller_manager_config.yaml, config.yaml or
+// manager-config.yaml. Returns false if given config is not a ControllerManagerConfig or has no recognized sections.
+func groupManagerConfig(config string, values helmify.Values) (string, bool, error) {
+	obj := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(config), &obj); err != nil || obj["kind"] != managerConfigKind {
+		return "", false, nil
+	}
+	grouped := false
+	for _, block := range managerConfigBlocks {
+		section, ok := obj[block].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		templated, err := templateLeaves(section, values, block)
+		if err != nil {
+			return "", false, err
+		}
+		obj[block] = templated
+		grouped = true
+	}
+	if !grouped {
+		return "", false, nil
+	}
+	res, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", false, fmt.Errorf("%w: unable to marshal manager config", err)
+	}
+	return format.UnquoteTemplates(string(res)), true, nil
+}
+
+// templateLeaves - replaces scalar values of given map with templates to values under given path.
+// Lists are kept as is.
+func templateLeaves(obj map[string]interface{}, values helmify.Values, path ...string) (map[string]interface{}, error) {
+	for key, val := range obj {
+		valPath := append(append([]string{}, path...), key)
+		switch v := val.(type) {
+		case map[string]interface{}:
+			templated, err := templateLeaves(v, values, valPath...)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = templated
+		case []interface{}, nil:
+		default:
+			templated, err := values.Add(v, valPath...)
+			if err != nil {
+				return nil, err
+			}
+			obj[key] = templated
+		}
+	}
+	return obj, nil
+}
@@ -0,0 +1,23 @@
+package processor
+
+import (
+	"strings"
+
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/iancoleman/strcase"
+)
+
+// ContainerValuesName - returns values key for a container from the given pod spec containers list, e.g. 'initContainers'.
+// With config.NestContainerValues init and ephemeral containers are nested under their list name, e.g.
+// 'initContainers.migrate', to avoid collisions with main containers having the same name.
+func ContainerValuesName(conf config.Config, containerType, name string) string {
+	if conf.NestContainerValues && containerType != "containers" {
+		return containerType + "." + strcase.ToLowerCamel(name)
+	}
+	return strcase.ToLowerCamel(name)
+}
+
+// ContainerValuesPath - returns values path of given container field, see ContainerValuesName.
+func ContainerValuesPath(objName, containerName string, fields ...string) []string {
+	return append(append([]string{objName}, strings.Split(containerName, ".")...), fields...)
+}
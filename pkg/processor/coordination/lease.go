@@ -0,0 +1,78 @@
+package coordination
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var leaseGVK = schema.GroupVersionKind{
+	Group:   "coordination.k8s.io",
+	Version: "v1",
+	Kind:    "Lease",
+}
+
+// leaseRuntimeFields - Lease spec fields set by the current leader at runtime.
+var leaseRuntimeFields = []string{"holderIdentity", "acquireTime", "renewTime", "leaseTransitions"}
+
+// NewLease creates processor for k8s Lease resource.
+func NewLease() helmify.Processor {
+	return &lease{}
+}
+
+type lease struct{}
+
+// Process k8s Lease object into template. Lease is renamed with chart fullname and placed to the release namespace.
+// Runtime fields of the current leader are dropped, so a new leader can acquire the lease on install.
+// Returns false if not capable of processing given resource type.
+func (l lease) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != leaseGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	meta = strings.Replace(meta, "metadata:\n", "metadata:\n  namespace: {{ .Release.Namespace }}\n", 1)
+
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	for _, field := range leaseRuntimeFields {
+		delete(spec, field)
+	}
+	var body string
+	if len(spec) != 0 {
+		body, err = yamlformat.Marshal(map[string]interface{}{"spec": spec}, 0)
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to marshal lease spec", err)
+		}
+		body = "\n" + body
+	}
+	return true, &result{
+		name: appMeta.TrimName(obj.GetName()) + ".yaml",
+		data: []byte(meta + body),
+	}, nil
+}
+
+type result struct {
+	name string
+	data []byte
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return helmify.Values{}
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
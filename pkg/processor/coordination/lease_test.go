@@ -0,0 +1,56 @@
+package coordination
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strLease = `apiVersion: coordination.k8s.io/v1
+kind: Lease
+metadata:
+  name: my-operator-leader-election
+  namespace: my-operator-system
+spec:
+  holderIdentity: my-operator-controller-manager-6c8f9d_0b8e
+  leaseDurationSeconds: 15
+  acquireTime: "2024-01-01T00:00:00.000000Z"
+  renewTime: "2024-01-01T00:10:00.000000Z"
+  leaseTransitions: 3`
+
+func Test_lease_Process(t *testing.T) {
+	var testInstance lease
+
+	t.Run("renamed and runtime fields dropped", func(t *testing.T) {
+		obj := internal.GenerateObj(strLease)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: my-operator-controller-manager"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, "leader-election.yaml", tmpl.Filename())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Equal(t, `apiVersion: coordination.k8s.io/v1
+kind: Lease
+metadata:
+  namespace: {{ .Release.Namespace }}
+  name: {{ include "chart.fullname" . }}-leader-election
+  labels:
+  {{- include "chart.labels" . | nindent 4 }}
+spec:
+  leaseDurationSeconds: 15`, buf.String())
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
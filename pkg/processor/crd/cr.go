@@ -0,0 +1,163 @@
+package crd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// NewCustomResource creates processor for custom resources defined by a CRD from the input.
+func NewCustomResource() helmify.Processor {
+	return &customResource{}
+}
+
+type customResource struct{}
+
+// Process custom resource into template. Scalar spec fields are lifted to values typed according to the CRD
+// OpenAPI schema. Returns false if the resource CRD is not in the input.
+func (c customResource) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	openAPISchema, ok := appMeta.CRDSchema(obj.GroupVersionKind())
+	if !ok {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	values := helmify.Values{}
+	body := map[string]interface{}{}
+	for key, val := range obj.Object {
+		switch key {
+		case "apiVersion", "kind", "metadata":
+			continue
+		}
+		body[key] = val
+	}
+	if spec, ok := body["spec"].(map[string]interface{}); ok {
+		if specSchema, ok := openAPISchema.Properties["spec"]; ok {
+			err = templateSpec(spec, &specSchema, values, nameCamel)
+			if err != nil {
+				return true, nil, err
+			}
+		}
+	}
+	res, err := yamlformat.MarshalTemplated(body, 0)
+	if err != nil {
+		return true, nil, err
+	}
+	return true, &crResult{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + res),
+		values: values,
+	}, nil
+}
+
+// templateSpec - replaces scalar fields of given object described by the schema with templates to values under
+// given path. Values are converted to the schema type. Lists, maps without properties and fields missing in the
+// schema are kept as is.
+func templateSpec(obj map[string]interface{}, objSchema *v1.JSONSchemaProps, values helmify.Values, path ...string) error {
+	for key, val := range obj {
+		fieldSchema, ok := objSchema.Properties[key]
+		if !ok || fieldSchema.XIntOrString {
+			continue
+		}
+		valPath := append(append([]string{}, path...), key)
+		if nested, ok := val.(map[string]interface{}); ok {
+			if fieldSchema.Type == "object" && len(fieldSchema.Properties) != 0 {
+				if err := templateSpec(nested, &fieldSchema, values, valPath...); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		typed, ok := schemaTyped(fieldSchema.Type, val)
+		if !ok {
+			continue
+		}
+		tpl, err := values.Add(typed, valPath...)
+		if err != nil {
+			return fmt.Errorf("%w: unable to template custom resource field %s", err, key)
+		}
+		obj[key] = tpl
+	}
+	return nil
+}
+
+// schemaTyped - converts given scalar value to OpenAPI schema type. Returns false if value is not convertible.
+func schemaTyped(schemaType string, val interface{}) (interface{}, bool) {
+	switch val.(type) {
+	case string, bool, int64, float64:
+	default:
+		return nil, false
+	}
+	switch schemaType {
+	case "string":
+		if s, ok := val.(string); ok {
+			return s, true
+		}
+		return fmt.Sprint(val), true
+	case "integer":
+		switch v := val.(type) {
+		case int64:
+			return v, true
+		case float64:
+			if v == float64(int64(v)) {
+				return int64(v), true
+			}
+		case string:
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i, true
+			}
+		}
+	case "number":
+		switch v := val.(type) {
+		case int64:
+			return float64(v), true
+		case float64:
+			return v, true
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				return f, true
+			}
+		}
+	case "boolean":
+		switch v := val.(type) {
+		case bool:
+			return v, true
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return b, true
+			}
+		}
+	}
+	return nil, false
+}
+
+type crResult struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *crResult) Filename() string {
+	return r.name
+}
+
+func (r *crResult) Values() helmify.Values {
+	return r.values
+}
+
+func (r *crResult) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
@@ -0,0 +1,99 @@
+package crd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const cacheCRDYaml = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: caches.example.com
+spec:
+  group: example.com
+  names:
+    kind: Cache
+    listKind: CacheList
+    plural: caches
+    singular: cache
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            properties:
+              size:
+                type: integer
+              version:
+                type: string
+              persistence:
+                type: object
+                properties:
+                  enabled:
+                    type: boolean
+              zones:
+                type: array
+                items:
+                  type: string`
+
+const cacheYaml = `apiVersion: example.com/v1
+kind: Cache
+metadata:
+  name: my-app-cache
+spec:
+  size: 3
+  version: 7.0
+  persistence:
+    enabled: true
+  zones:
+  - a
+  - b
+  unknown: kept`
+
+func Test_customResource_Process(t *testing.T) {
+	var testInstance customResource
+
+	t.Run("typed values from crd schema", func(t *testing.T) {
+		obj := internal.GenerateObj(cacheYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(internal.GenerateObj(cacheCRDYaml))
+		appMeta.Load(obj)
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		assert.Equal(t, helmify.Values{
+			"myAppCache": map[string]interface{}{
+				"size":        int64(3),
+				"version":     "7",
+				"persistence": map[string]interface{}{"enabled": true},
+			},
+		}, tmpl.Values())
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "size: {{ .Values.myAppCache.size }}")
+		assert.Contains(t, buf.String(), "version: {{ .Values.myAppCache.version | quote }}")
+		assert.Contains(t, buf.String(), "enabled: {{ .Values.myAppCache.persistence.enabled }}")
+		assert.Contains(t, buf.String(), "unknown: kept")
+		assert.Contains(t, buf.String(), "zones:\n  - a\n  - b")
+	})
+	t.Run("skipped without crd", func(t *testing.T) {
+		obj := internal.GenerateObj(cacheYaml)
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
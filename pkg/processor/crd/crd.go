@@ -14,17 +14,16 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
 	yamlformat "github.com/arttor/helmify/pkg/yaml"
 )
 
-const crdTeml = `apiVersion: apiextensions.k8s.io/v1
+const crdTeml = `apiVersion: %[6]s
 kind: CustomResourceDefinition
 metadata:
   name: %[1]s
 %[3]s
-  labels:
 %[4]s
-  {{- include "%[2]s.labels" . | nindent 4 }}
 spec:
 %[5]s
 status:
@@ -71,14 +70,7 @@ func (c crd) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 
 	var labels, annotations string
 	if len(obj.GetAnnotations()) != 0 {
-		a := obj.GetAnnotations()
-		certName := a["cert-manager.io/inject-ca-from"]
-		if certName != "" {
-			certName = strings.TrimPrefix(certName, appMeta.Namespace()+"/")
-			certName = appMeta.TrimName(certName)
-			a["cert-manager.io/inject-ca-from"] = fmt.Sprintf(`{{ .Release.Namespace }}/{{ include "%[1]s.fullname" . }}-%[2]s`, appMeta.ChartName(), certName)
-		}
-		annotations, err = yamlformat.Marshal(map[string]interface{}{"annotations": a}, 2)
+		annotations, err = processor.MarshalAnnotations(appMeta, obj.GetAnnotations(), 2)
 		if err != nil {
 			return true, nil, err
 		}
@@ -126,7 +118,7 @@ func (c crd) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 	specYaml = yamlformat.Indent(specYaml, 2)
 	specYaml = bytes.TrimRight(specYaml, "\n ")
 
-	res := fmt.Sprintf(crdTeml, obj.GetName(), appMeta.ChartName(), annotations, labels, string(specYaml))
+	res := fmt.Sprintf(crdTeml, obj.GetName(), appMeta.ChartName(), annotations, processor.LabelsBlock(appMeta, labels), string(specYaml), processor.APIVersion(appMeta, obj))
 	res = strings.ReplaceAll(res, "\n\n", "\n")
 
 	return true, &result{
@@ -66,9 +66,13 @@ func (d daemonset) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 
 	name := appMeta.TrimName(obj.GetName())
 
-	matchLabels, err := yamlformat.Marshal(map[string]interface{}{"matchLabels": dae.Spec.Selector.MatchLabels}, 0)
-	if err != nil {
-		return true, nil, err
+	// selector labels are added by chart helpers, so source labels are optional.
+	matchLabels := "matchLabels:"
+	if len(dae.Spec.Selector.MatchLabels) != 0 {
+		matchLabels, err = yamlformat.Marshal(map[string]interface{}{"matchLabels": dae.Spec.Selector.MatchLabels}, 0)
+		if err != nil {
+			return true, nil, err
+		}
 	}
 	matchExpr := ""
 	if dae.Spec.Selector.MatchExpressions != nil {
@@ -81,11 +85,15 @@ func (d daemonset) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 	selector = strings.Trim(selector, " \n")
 	selector = string(yamlformat.Indent([]byte(selector), 4))
 
-	podLabels, err := yamlformat.Marshal(dae.Spec.Template.ObjectMeta.Labels, 8)
-	if err != nil {
-		return true, nil, err
+	podLabels := ""
+	if len(dae.Spec.Template.ObjectMeta.Labels) != 0 {
+		podLabels, err = yamlformat.Marshal(dae.Spec.Template.ObjectMeta.Labels, 8)
+		if err != nil {
+			return true, nil, err
+		}
 	}
 	podLabels += fmt.Sprintf("\n      {{- include \"%s.selectorLabels\" . | nindent 8 }}", appMeta.ChartName())
+	podLabels = strings.TrimPrefix(podLabels, "\n")
 
 	podAnnotations := ""
 	if len(dae.Spec.Template.ObjectMeta.Annotations) != 0 {
@@ -98,10 +106,17 @@ func (d daemonset) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstru
 	}
 
 	nameCamel := strcase.ToLowerCamel(name)
+	if err := pod.ReportDroppedFields(appMeta, obj, dae.Spec.Template.Spec, "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
 	specMap, podValues, err := pod.ProcessSpec(nameCamel, appMeta, dae.Spec.Template.Spec)
 	if err != nil {
 		return true, nil, err
 	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamel, appMeta, specMap, &podValues, obj.Object, "spec", "template", "spec")
+	if err != nil {
+		return true, nil, err
+	}
 	err = values.Merge(podValues)
 	if err != nil {
 		return true, nil, err
@@ -1,6 +1,7 @@
 package daemonset
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/arttor/helmify/pkg/metadata"
@@ -56,6 +57,27 @@ spec:
         hostPath:
           path: /var/lib/docker/containers
 `
+
+	strHostNetwork = `apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: node-agent
+  namespace: kube-system
+spec:
+  selector:
+    matchLabels:
+      name: node-agent
+  template:
+    metadata:
+      labels:
+        name: node-agent
+    spec:
+      hostNetwork: true
+      hostPID: true
+      containers:
+      - name: agent
+        image: agent:1.0.0
+`
 )
 
 func Test_daemonset_Process(t *testing.T) {
@@ -73,4 +95,23 @@ func Test_daemonset_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, false, processed)
 	})
+	t.Run("host network", func(t *testing.T) {
+		obj := internal.GenerateObj(strHostNetwork)
+		processed, tpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tpl.Write(&buf))
+		assert.Contains(t, buf.String(), "hostNetwork: {{ .Values.nodeAgent.hostNetwork }}")
+		assert.Contains(t, buf.String(), "hostPID: {{ .Values.nodeAgent.hostPID }}")
+		assert.Contains(t, buf.String(), `dnsPolicy: {{ ternary "ClusterFirstWithHostNet" "ClusterFirst" .Values.nodeAgent.hostNetwork`)
+		assert.NotContains(t, buf.String(), "hostIPC")
+
+		nodeAgent := tpl.Values()["nodeAgent"].(map[string]interface{})
+		assert.Equal(t, true, nodeAgent["hostNetwork"])
+		assert.Equal(t, true, nodeAgent["hostPID"])
+		assert.NotContains(t, nodeAgent, "dnsPolicy")
+		assert.NotContains(t, nodeAgent, "hostIPC")
+	})
 }
@@ -32,6 +32,9 @@ spec:
 {{- end }}
 {{- if .RevisionHistoryLimit }}
 {{ .RevisionHistoryLimit }}
+{{- end }}
+{{- if .ProgressDeadlineSeconds }}
+{{ .ProgressDeadlineSeconds }}
 {{- end }}
   selector:
 {{ .Selector }}
@@ -83,10 +86,19 @@ func (d deployment) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstr
 		return true, nil, err
 	}
 
-	matchLabels, err := yamlformat.Marshal(map[string]interface{}{"matchLabels": depl.Spec.Selector.MatchLabels}, 0)
+	progressDeadlineSeconds, err := processProgressDeadlineSeconds(name, &depl, &values)
 	if err != nil {
 		return true, nil, err
 	}
+
+	// selector labels are added by chart helpers, so source labels are optional.
+	matchLabels := "matchLabels:"
+	if len(depl.Spec.Selector.MatchLabels) != 0 {
+		matchLabels, err = yamlformat.Marshal(map[string]interface{}{"matchLabels": depl.Spec.Selector.MatchLabels}, 0)
+		if err != nil {
+			return true, nil, err
+		}
+	}
 	matchExpr := ""
 	if depl.Spec.Selector.MatchExpressions != nil {
 		matchExpr, err = yamlformat.Marshal(map[string]interface{}{"matchExpressions": depl.Spec.Selector.MatchExpressions}, 0)
@@ -98,11 +110,15 @@ func (d deployment) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstr
 	selector = strings.Trim(selector, " \n")
 	selector = string(yamlformat.Indent([]byte(selector), 4))
 
-	podLabels, err := yamlformat.Marshal(depl.Spec.Template.ObjectMeta.Labels, 8)
-	if err != nil {
-		return true, nil, err
+	podLabels := ""
+	if len(depl.Spec.Template.ObjectMeta.Labels) != 0 {
+		podLabels, err = yamlformat.Marshal(depl.Spec.Template.ObjectMeta.Labels, 8)
+		if err != nil {
+			return true, nil, err
+		}
 	}
 	podLabels += fmt.Sprintf("\n      {{- include \"%s.selectorLabels\" . | nindent 8 }}", appMeta.ChartName())
+	podLabels = strings.TrimPrefix(podLabels, "\n")
 
 	podAnnotations := ""
 	if len(depl.Spec.Template.ObjectMeta.Annotations) != 0 {
@@ -115,10 +131,17 @@ func (d deployment) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstr
 	}
 
 	nameCamel := strcase.ToLowerCamel(name)
+	if err := pod.ReportDroppedFields(appMeta, obj, depl.Spec.Template.Spec, "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
 	specMap, podValues, err := pod.ProcessSpec(nameCamel, appMeta, depl.Spec.Template.Spec)
 	if err != nil {
 		return true, nil, err
 	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamel, appMeta, specMap, &podValues, obj.Object, "spec", "template", "spec")
+	if err != nil {
+		return true, nil, err
+	}
 	err = values.Merge(podValues)
 	if err != nil {
 		return true, nil, err
@@ -134,21 +157,23 @@ func (d deployment) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstr
 	return true, &result{
 		values: values,
 		data: struct {
-			Meta                 string
-			Replicas             string
-			RevisionHistoryLimit string
-			Selector             string
-			PodLabels            string
-			PodAnnotations       string
-			Spec                 string
+			Meta                    string
+			Replicas                string
+			RevisionHistoryLimit    string
+			ProgressDeadlineSeconds string
+			Selector                string
+			PodLabels               string
+			PodAnnotations          string
+			Spec                    string
 		}{
-			Meta:                 meta,
-			Replicas:             replicas,
-			RevisionHistoryLimit: revisionHistoryLimit,
-			Selector:             selector,
-			PodLabels:            podLabels,
-			PodAnnotations:       podAnnotations,
-			Spec:                 spec,
+			Meta:                    meta,
+			Replicas:                replicas,
+			RevisionHistoryLimit:    revisionHistoryLimit,
+			ProgressDeadlineSeconds: progressDeadlineSeconds,
+			Selector:                selector,
+			PodLabels:               podLabels,
+			PodAnnotations:          podAnnotations,
+			Spec:                    spec,
 		},
 	}, nil
 }
@@ -185,15 +210,27 @@ func processRevisionHistoryLimit(name string, deployment *appsv1.Deployment, val
 	return revisionHistoryLimit, nil
 }
 
+func processProgressDeadlineSeconds(name string, deployment *appsv1.Deployment, values *helmify.Values) (string, error) {
+	if deployment.Spec.ProgressDeadlineSeconds == nil {
+		return "", nil
+	}
+	progressDeadlineSecondsTpl, err := values.Add(int64(*deployment.Spec.ProgressDeadlineSeconds), name, "progressDeadlineSeconds")
+	if err != nil {
+		return "", err
+	}
+	return yamlformat.MarshalTemplated(map[string]interface{}{"progressDeadlineSeconds": progressDeadlineSecondsTpl}, 2)
+}
+
 type result struct {
 	data struct {
-		Meta                 string
-		Replicas             string
-		RevisionHistoryLimit string
-		Selector             string
-		PodLabels            string
-		PodAnnotations       string
-		Spec                 string
+		Meta                    string
+		Replicas                string
+		RevisionHistoryLimit    string
+		ProgressDeadlineSeconds string
+		Selector                string
+		PodLabels               string
+		PodAnnotations          string
+		Spec                    string
 	}
 	values helmify.Values
 }
@@ -1,9 +1,14 @@
 package deployment
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
 	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
 
 	"github.com/arttor/helmify/internal"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +24,7 @@ metadata:
   namespace: my-operator-system
 spec:
   revisionHistoryLimit: 5
+  progressDeadlineSeconds: 300
   replicas: 1
   selector:
     matchLabels:
@@ -129,4 +135,109 @@ func Test_deployment_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, false, processed)
 	})
+	t.Run("revisionHistoryLimit and progressDeadlineSeconds", func(t *testing.T) {
+		obj := internal.GenerateObj(strDepl)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		name := "myOperatorControllerManager"
+		assert.Equal(t, int64(5), tmpl.Values()[name].(map[string]interface{})["revisionHistoryLimit"])
+		assert.Equal(t, int64(300), tmpl.Values()[name].(map[string]interface{})["progressDeadlineSeconds"])
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "  revisionHistoryLimit: {{ .Values.myOperatorControllerManager.revisionHistoryLimit")
+		assert.Contains(t, buf.String(), "  progressDeadlineSeconds: {{ .Values.myOperatorControllerManager.progressDeadlineSeconds")
+	})
+	t.Run("values nested by resource name", func(t *testing.T) {
+		obj := internal.GenerateObj(strDepl)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		deplValues, ok := tmpl.Values()["myOperatorControllerManager"].(map[string]interface{})
+		assert.True(t, ok)
+		manager, ok := deplValues["manager"].(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, map[string]interface{}{
+			"repository": "controller",
+			"tag":        "latest",
+		}, manager["image"])
+		assert.Contains(t, manager, "resources")
+		assert.Contains(t, deplValues, "kubeRbacProxy")
+		assert.Contains(t, deplValues, "replicas")
+	})
+	t.Run("pinned apiVersion", func(t *testing.T) {
+		obj := internal.GenerateObj(strDepl)
+		appMeta := metadata.New(config.Config{ChartName: "chart", APIVersions: map[string]string{"Deployment": "apps/v1beta2"}})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.True(t, strings.HasPrefix(buf.String(), "apiVersion: apps/v1beta2\nkind: Deployment\n"), buf.String())
+	})
+	t.Run("native sidecar init container", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      initContainers:
+      - name: proxy
+        image: envoy:1.28
+        restartPolicy: Always
+      - name: migrate
+        image: migrate:1.0
+      containers:
+      - name: web
+        image: nginx:1.25`)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "restartPolicy: {{ .Values.web.proxy.restartPolicy }}")
+		assert.Equal(t, 1, strings.Count(buf.String(), "restartPolicy:"))
+		proxy := tmpl.Values()["web"].(map[string]interface{})["proxy"].(map[string]interface{})
+		assert.Equal(t, "Always", proxy["restartPolicy"])
+	})
+	t.Run("dropped pod spec fields reported", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      initContainers:
+      - name: proxy
+        image: envoy:1.28
+        restartPolicy: Always
+      containers:
+      - name: web
+        image: nginx:1.25
+        resizePolicy:
+        - resourceName: cpu
+          restartPolicy: NotRequired`)
+		_, _, err := testInstance.Process(metadata.New(config.Config{}), obj)
+		assert.NoError(t, err, "reported as warning")
+		_, _, err = testInstance.Process(metadata.New(config.Config{Strict: true}), obj)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+		assert.EqualError(t, err, "lossy conversion: Deployment web: pod spec fields dropped: containers[0].resizePolicy")
+	})
 }
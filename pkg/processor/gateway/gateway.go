@@ -0,0 +1,101 @@
+package gateway
+
+import (
+	"fmt"
+	"text/template"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var gatewayTempl, _ = template.New("gateway").Parse(
+	`{{ .Meta }}
+{{ .Spec }}`)
+
+var gatewayGVC = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1",
+	Kind:    "Gateway",
+}
+
+// New creates processor for Gateway API Gateway resource.
+func New() helmify.Processor {
+	return &gateway{}
+}
+
+type gateway struct{}
+
+// Process Gateway API Gateway object into template. Returns false if not capable of processing given resource type.
+func (g gateway) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != gatewayGVC {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get gateway spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no gateway spec presented")
+	}
+
+	values := helmify.Values{}
+	if className, ok, _ := unstructured.NestedString(specMap, "gatewayClassName"); ok {
+		templatedClassName, err := values.Add(className, nameCamel, "gatewayClassName")
+		if err != nil {
+			return true, nil, err
+		}
+		specMap["gatewayClassName"] = templatedClassName
+	}
+
+	listeners, _, err := unstructured.NestedSlice(specMap, "listeners")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get gateway listeners", err)
+	}
+	for _, listener := range listeners {
+		listenerMap, ok := listener.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		listenerName, _, _ := unstructured.NestedString(listenerMap, "name")
+		if hostname, ok, _ := unstructured.NestedString(listenerMap, "hostname"); ok && listenerName != "" {
+			templatedHostname, err := values.Add(hostname, nameCamel, "listeners", listenerName, "hostname")
+			if err != nil {
+				return true, nil, err
+			}
+			listenerMap["hostname"] = templatedHostname
+		}
+		err = templateRefs(appMeta, listenerMap, "Secret", "tls", "certificateRefs")
+		if err != nil {
+			return true, nil, err
+		}
+	}
+	if len(listeners) != 0 {
+		specMap["listeners"] = listeners
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name: name + ".yaml",
+		data: struct {
+			Meta string
+			Spec string
+		}{Meta: meta, Spec: spec},
+		values: values,
+		templ:  gatewayTempl,
+	}, nil
+}
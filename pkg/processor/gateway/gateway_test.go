@@ -0,0 +1,52 @@
+package gateway
+
+import (
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const gatewayYaml = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: myapp-gateway
+spec:
+  gatewayClassName: nginx
+  listeners:
+  - name: https
+    protocol: HTTPS
+    port: 443
+    hostname: myapp.example.com
+    tls:
+      certificateRefs:
+      - name: myapp-tls`
+
+func Test_gateway_Process(t *testing.T) {
+	var testInstance gateway
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(gatewayYaml)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, helmify.Values{
+			"myappGateway": map[string]interface{}{
+				"gatewayClassName": "nginx",
+				"listeners": map[string]interface{}{
+					"https": map[string]interface{}{
+						"hostname": "myapp.example.com",
+					},
+				},
+			},
+		}, tmpl.Values())
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
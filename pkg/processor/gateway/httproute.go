@@ -0,0 +1,157 @@
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var routeTempl, _ = template.New("httpRoute").Parse(
+	`{{ .Meta }}
+{{ .Spec }}`)
+
+var httpRouteGVC = schema.GroupVersionKind{
+	Group:   "gateway.networking.k8s.io",
+	Version: "v1",
+	Kind:    "HTTPRoute",
+}
+
+// NewHTTPRoute creates processor for Gateway API HTTPRoute resource.
+func NewHTTPRoute() helmify.Processor {
+	return &httpRoute{}
+}
+
+type httpRoute struct{}
+
+// Process Gateway API HTTPRoute object into template. Returns false if not capable of processing given resource type.
+func (r httpRoute) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != httpRouteGVC {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get httpRoute spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no httpRoute spec presented")
+	}
+
+	values := helmify.Values{}
+	err = templateRefs(appMeta, specMap, "Gateway", "parentRefs")
+	if err != nil {
+		return true, nil, err
+	}
+
+	hostnames, _, err := unstructured.NestedSlice(specMap, "hostnames")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get httpRoute hostnames", err)
+	}
+	if len(hostnames) != 0 {
+		templatedHostnames, err := values.AddYaml(hostnames, 4, true, nameCamel, "hostnames")
+		if err != nil {
+			return true, nil, err
+		}
+		specMap["hostnames"] = templatedHostnames
+	}
+
+	rules, _, err := unstructured.NestedSlice(specMap, "rules")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get httpRoute rules", err)
+	}
+	for _, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		err = templateRefs(appMeta, ruleMap, "Service", "backendRefs")
+		if err != nil {
+			return true, nil, err
+		}
+	}
+	if len(rules) != 0 {
+		specMap["rules"] = rules
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name: name + ".yaml",
+		data: struct {
+			Meta string
+			Spec string
+		}{Meta: meta, Spec: spec},
+		values: values,
+		templ:  routeTempl,
+	}, nil
+}
+
+// templateRefs replaces names of object references found under the given field with templated names.
+// References to kinds other than defaultKind are left as is. Same for references to objects in other namespaces.
+func templateRefs(appMeta helmify.AppMetadata, obj map[string]interface{}, defaultKind string, fields ...string) error {
+	refs, exists, err := unstructured.NestedSlice(obj, fields...)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get %s", err, strings.Join(fields, "."))
+	}
+	if !exists {
+		return nil
+	}
+	for _, ref := range refs {
+		refMap, ok := ref.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, _, _ := unstructured.NestedString(refMap, "kind"); kind != "" && kind != defaultKind {
+			continue
+		}
+		if ns, _, _ := unstructured.NestedString(refMap, "namespace"); ns != "" {
+			if ns != appMeta.Namespace() {
+				continue
+			}
+			refMap["namespace"] = "{{ .Release.Namespace }}"
+		}
+		if name, _, _ := unstructured.NestedString(refMap, "name"); name != "" {
+			refMap["name"] = appMeta.TemplatedName(name)
+		}
+	}
+	return unstructured.SetNestedSlice(obj, refs, fields...)
+}
+
+type result struct {
+	name string
+	data struct {
+		Meta string
+		Spec string
+	}
+	values helmify.Values
+	templ  *template.Template
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	return r.templ.Execute(writer, r.data)
+}
@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	httpRouteYaml = `apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: myapp-route
+  namespace: myapp-ns
+spec:
+  parentRefs:
+  - name: myapp-gateway
+  hostnames:
+  - myapp.example.com
+  rules:
+  - matches:
+    - path:
+        type: PathPrefix
+        value: /api
+    backendRefs:
+    - name: myapp-service
+      port: 8080`
+	httpRouteSvcYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: myapp-service
+  namespace: myapp-ns`
+	httpRouteGatewayYaml = `apiVersion: gateway.networking.k8s.io/v1
+kind: Gateway
+metadata:
+  name: myapp-gateway
+  namespace: myapp-ns`
+)
+
+func Test_httpRoute_Process(t *testing.T) {
+	var testInstance httpRoute
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(httpRouteYaml)
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+	t.Run("backendRef references generated service", func(t *testing.T) {
+		obj := internal.GenerateObj(httpRouteYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(httpRouteSvcYaml))
+		appMeta.Load(internal.GenerateObj(httpRouteGatewayYaml))
+
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `- name: {{ include "chart.fullname" . }}-service`)
+		assert.Contains(t, buf.String(), `- name: {{ include "chart.fullname" . }}-gateway`)
+		assert.Contains(t, buf.String(), `hostnames: {{ .Values.route.hostnames | toYaml | nindent 4 }}`)
+		assert.Contains(t, buf.String(), `value: /api`)
+		assert.Equal(t, helmify.Values{
+			"route": map[string]interface{}{
+				"hostnames": []interface{}{"myapp.example.com"},
+			},
+		}, tmpl.Values())
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 )
@@ -20,6 +22,11 @@ var cronTempl, _ = template.New("cron").Parse(
 	`{{ .Meta }}
 {{ .Spec }}`)
 
+// podSpecIndentShift - CronJob pod spec is nested 4 spaces deeper than the Deployment one pod.ProcessSpec expects.
+const podSpecIndentShift = 4
+
+var nindentRe = regexp.MustCompile(`\| nindent (\d+) }}`)
+
 var cronGVC = schema.GroupVersionKind{
 	Group:   "batch",
 	Version: "v1",
@@ -104,11 +111,18 @@ func (p cron) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructure
 		}
 	}
 
+	if err := pod.ReportDroppedFields(appMeta, obj, jobObj.Spec.JobTemplate.Spec.Template.Spec, "spec", "jobTemplate", "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
 	// process job pod template:
 	podSpecMap, podValues, err := pod.ProcessSpec(nameCamelCase, appMeta, jobObj.Spec.JobTemplate.Spec.Template.Spec)
 	if err != nil {
 		return true, nil, err
 	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamelCase, appMeta, podSpecMap, &podValues, obj.Object, "spec", "jobTemplate", "spec", "template", "spec")
+	if err != nil {
+		return true, nil, err
+	}
 	err = values.Merge(podValues)
 	if err != nil {
 		return true, nil, err
@@ -124,6 +138,7 @@ func (p cron) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructure
 		return true, nil, err
 	}
 	specStr = strings.ReplaceAll(specStr, "'", "")
+	specStr = shiftNindent(specStr, podSpecIndentShift)
 
 	return true, &resultCron{
 		name: name + ".yaml",
@@ -135,6 +150,14 @@ func (p cron) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructure
 	}, nil
 }
 
+// shiftNindent - increases indentation of all nindent pipelines in str by shift.
+func shiftNindent(str string, shift int) string {
+	return nindentRe.ReplaceAllStringFunc(str, func(match string) string {
+		indent, _ := strconv.Atoi(nindentRe.FindStringSubmatch(match)[1])
+		return fmt.Sprintf("| nindent %d }}", indent+shift)
+	})
+}
+
 type resultCron struct {
 	name string
 	data struct {
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"bytes"
 	"github.com/arttor/helmify/internal"
 	"github.com/arttor/helmify/pkg/metadata"
 	"github.com/stretchr/testify/assert"
@@ -27,6 +28,24 @@ spec:
                 - -c
                 - date; echo Hello from the Kubernetes cluster
           restartPolicy: OnFailure`
+
+	strCronHistoryLimits = `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: cron-job
+spec:
+  schedule: "0 * * * *"
+  successfulJobsHistoryLimit: 5
+  failedJobsHistoryLimit: 2
+  startingDeadlineSeconds: 120
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: hello
+              image: busybox:1.28
+          restartPolicy: OnFailure`
 )
 
 func Test_Cron_Process(t *testing.T) {
@@ -38,6 +57,40 @@ func Test_Cron_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("command lifted to values", func(t *testing.T) {
+		obj := internal.GenerateObj(strCron)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"/bin/sh", "-c", "date; echo Hello from the Kubernetes cluster"}, tmpl.Values()["cronJob"].(map[string]interface{})["hello"].(map[string]interface{})["command"])
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "- command: {{- toYaml .Values.cronJob.hello.command | nindent 12 }}")
+	})
+	t.Run("history limits and starting deadline lifted to values", func(t *testing.T) {
+		obj := internal.GenerateObj(strCronHistoryLimits)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		cronValues := tmpl.Values()["cronJob"].(map[string]interface{})
+		assert.Equal(t, int64(5), cronValues["successfulJobsHistoryLimit"])
+		assert.Equal(t, int64(2), cronValues["failedJobsHistoryLimit"])
+		assert.Equal(t, int64(120), cronValues["startingDeadlineSeconds"])
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "successfulJobsHistoryLimit: {{ .Values.cronJob.successfulJobsHistoryLimit }}")
+		assert.Contains(t, buf.String(), "failedJobsHistoryLimit: {{ .Values.cronJob.failedJobsHistoryLimit }}")
+		assert.Contains(t, buf.String(), "startingDeadlineSeconds: {{ .Values.cronJob.startingDeadlineSeconds }}")
+	})
+	t.Run("history limits omitted if not set", func(t *testing.T) {
+		obj := internal.GenerateObj(strCron)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		cronValues := tmpl.Values()["cronJob"].(map[string]interface{})
+		for _, field := range []string{"successfulJobsHistoryLimit", "failedJobsHistoryLimit", "startingDeadlineSeconds"} {
+			assert.NotContains(t, cronValues, field)
+		}
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
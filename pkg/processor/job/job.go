@@ -97,17 +97,24 @@ func (p job) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 		}
 	}
 
-	if spec.ActiveDeadlineSeconds != nil {
-		err := templateSpecVal(*spec.ActiveDeadlineSeconds, &values, specMap, nameCamelCase, "activeDeadlineSeconds")
+	if spec.TTLSecondsAfterFinished != nil {
+		err := templateSpecVal(*spec.TTLSecondsAfterFinished, &values, specMap, nameCamelCase, "ttlSecondsAfterFinished")
 		if err != nil {
 			return true, nil, err
 		}
 	}
+	if err := pod.ReportDroppedFields(appMeta, obj, jobObj.Spec.Template.Spec, "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
 	// process job pod template:
 	podSpecMap, podValues, err := pod.ProcessSpec(nameCamelCase, appMeta, jobObj.Spec.Template.Spec)
 	if err != nil {
 		return true, nil, err
 	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamelCase, appMeta, podSpecMap, &podValues, obj.Object, "spec", "template", "spec")
+	if err != nil {
+		return true, nil, err
+	}
 	err = values.Merge(podValues)
 	if err != nil {
 		return true, nil, err
@@ -1,6 +1,7 @@
 package job
 
 import (
+	"bytes"
 	"github.com/arttor/helmify/internal"
 	"github.com/arttor/helmify/pkg/metadata"
 	"github.com/stretchr/testify/assert"
@@ -21,6 +22,21 @@ spec:
           command: ["perl",  "-Mbignum=bpi", "-wle", "print bpi(2000)"]
       restartPolicy: Never
   backoffLimit: 4`
+
+	strParallelJob = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: parallel-job
+spec:
+  parallelism: 3
+  completions: 6
+  ttlSecondsAfterFinished: 100
+  template:
+    spec:
+      containers:
+        - name: worker
+          image: busybox:1.36
+      restartPolicy: Never`
 )
 
 func Test_configMap_Process(t *testing.T) {
@@ -32,6 +48,26 @@ func Test_configMap_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("parallel job", func(t *testing.T) {
+		obj := internal.GenerateObj(strParallelJob)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		values := tmpl.Values()
+		assert.EqualValues(t, 3, values["parallelJob"].(map[string]interface{})["parallelism"])
+		assert.EqualValues(t, 6, values["parallelJob"].(map[string]interface{})["completions"])
+		assert.EqualValues(t, 100, values["parallelJob"].(map[string]interface{})["ttlSecondsAfterFinished"])
+		assert.NotContains(t, values["parallelJob"], "backoffLimit")
+		assert.NotContains(t, values["parallelJob"], "activeDeadlineSeconds")
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "parallelism: {{ .Values.parallelJob.parallelism }}")
+		assert.Contains(t, buf.String(), "completions: {{ .Values.parallelJob.completions }}")
+		assert.Contains(t, buf.String(), "ttlSecondsAfterFinished: {{ .Values.parallelJob.ttlSecondsAfterFinished }}")
+		assert.NotContains(t, buf.String(), "backoffLimit")
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
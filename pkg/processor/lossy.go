@@ -0,0 +1,87 @@
+package processor
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrLossyConversion - returned in strict mode when a resource can not be converted to template without losing data.
+var ErrLossyConversion = errors.New("lossy conversion")
+
+// ReportLossy - reports that a part of given object was dropped or was not templated because of reason.
+// Returns wrapped ErrLossyConversion in strict mode. Otherwise, logs a warning and returns nil.
+func ReportLossy(appMeta helmify.AppMetadata, obj *unstructured.Unstructured, reason string) error {
+	if appMeta.Config().Strict {
+		return fmt.Errorf("%w: %s %s: %s", ErrLossyConversion, obj.GetKind(), obj.GetName(), reason)
+	}
+	logrus.WithFields(logrus.Fields{
+		"ApiVersion": obj.GetAPIVersion(),
+		"Kind":       obj.GetKind(),
+		"Name":       obj.GetName(),
+	}).Warn(reason)
+	return nil
+}
+
+// DroppedFields - returns paths of non-empty fields of source missing in typed, e.g. fields unknown to the used k8s
+// API version dropped on conversion of unstructured object to typed one and back. Paths are sorted, list items are
+// addressed by index: containers[0].resizePolicy.
+func DroppedFields(source, typed interface{}) []string {
+	var res []string
+	droppedFields("", source, typed, &res)
+	sort.Strings(res)
+	return res
+}
+
+func droppedFields(path string, source, typed interface{}, res *[]string) {
+	switch s := source.(type) {
+	case map[string]interface{}:
+		t, _ := typed.(map[string]interface{})
+		for key, val := range s {
+			keyPath := key
+			if path != "" {
+				keyPath = path + "." + key
+			}
+			typedVal, ok := t[key]
+			if !ok {
+				if !isEmpty(val) {
+					*res = append(*res, keyPath)
+				}
+				continue
+			}
+			droppedFields(keyPath, val, typedVal, res)
+		}
+	case []interface{}:
+		t, _ := typed.([]interface{})
+		for i, val := range s {
+			if i < len(t) {
+				droppedFields(fmt.Sprintf("%s[%d]", path, i), val, t[i], res)
+			}
+		}
+	}
+}
+
+// isEmpty - reports whether given unstructured value is zero, omitted by typed objects.
+func isEmpty(val interface{}) bool {
+	switch v := val.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case int64:
+		return v == 0
+	case float64:
+		return v == 0
+	}
+	return false
+}
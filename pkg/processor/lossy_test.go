@@ -0,0 +1,39 @@
+package processor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportLossy(t *testing.T) {
+	obj := internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cfg")
+	assert.NoError(t, ReportLossy(metadata.New(config.Config{}), obj, "data dropped"))
+	err := ReportLossy(metadata.New(config.Config{Strict: true}), obj, "data dropped")
+	assert.True(t, errors.Is(err, ErrLossyConversion))
+	assert.EqualError(t, err, "lossy conversion: ConfigMap cfg: data dropped")
+}
+
+func TestDroppedFields(t *testing.T) {
+	source := map[string]interface{}{
+		"hostNetwork":  false,
+		"nodeSelector": map[string]interface{}{},
+		"containers": []interface{}{
+			map[string]interface{}{"name": "web", "resizePolicy": []interface{}{map[string]interface{}{"resourceName": "cpu"}}},
+			map[string]interface{}{"name": "sidecar", "unknown": "value"},
+		},
+		"schedulingGates": []interface{}{map[string]interface{}{"name": "gate"}},
+	}
+	typed := map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "web", "resources": map[string]interface{}{}},
+			map[string]interface{}{"name": "sidecar"},
+		},
+	}
+	assert.Equal(t, []string{"containers[0].resizePolicy", "containers[1].unknown", "schedulingGates"}, DroppedFields(source, typed))
+	assert.Empty(t, DroppedFields(typed, typed))
+}
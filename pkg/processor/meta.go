@@ -16,10 +16,10 @@ const metaTemplate = `apiVersion: %[1]s
 kind: %[2]s
 metadata:
   name: %[3]s
-  labels:
-%[5]s
-  {{- include "%[4]s.labels" . | nindent 4 }}
-%[6]s`
+%[4]s
+%[5]s`
+
+const labelsIncludeTemplate = `  {{- include "%s.labels" . | nindent 4 }}`
 
 const annotationsTemplate = `  annotations:
     {{- toYaml .Values.%[1]s.%[2]s.annotations | nindent 4 }}`
@@ -29,8 +29,9 @@ type MetaOpt interface {
 }
 
 type options struct {
-	values      helmify.Values
-	annotations bool
+	values       helmify.Values
+	annotations  bool
+	originalName bool
 }
 
 type annotationsOption struct {
@@ -48,6 +49,17 @@ func WithAnnotations(values helmify.Values) MetaOpt {
 	}
 }
 
+type originalNameOption struct{}
+
+func (o originalNameOption) apply(opts *options) {
+	opts.originalName = true
+}
+
+// WithOriginalName - keeps object name as it is instead of prefixing it with chart fullname.
+func WithOriginalName() MetaOpt {
+	return originalNameOption{}
+}
+
 // ProcessObjMeta - returns object apiVersion, kind and metadata as helm template.
 func ProcessObjMeta(appMeta helmify.AppMetadata, obj *unstructured.Unstructured, opts ...MetaOpt) (string, error) {
 	options := &options{}
@@ -75,14 +87,17 @@ func ProcessObjMeta(appMeta helmify.AppMetadata, obj *unstructured.Unstructured,
 		}
 	}
 	if len(obj.GetAnnotations()) != 0 {
-		annotations, err = yamlformat.Marshal(map[string]interface{}{"annotations": obj.GetAnnotations()}, 2)
+		annotations, err = MarshalAnnotations(appMeta, obj.GetAnnotations(), 2)
 		if err != nil {
 			return "", err
 		}
 	}
 
 	templatedName := appMeta.TemplatedName(obj.GetName())
-	apiVersion, kind := obj.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	if options.originalName {
+		templatedName = obj.GetName()
+	}
+	apiVersion, kind := APIVersion(appMeta, obj), obj.GetKind()
 
 	var metaStr string
 	if options.values != nil && options.annotations {
@@ -100,8 +115,33 @@ func ProcessObjMeta(appMeta helmify.AppMetadata, obj *unstructured.Unstructured,
 		annotations = fmt.Sprintf(annotationsTemplate, name, kind)
 	}
 
-	metaStr = fmt.Sprintf(metaTemplate, apiVersion, kind, templatedName, appMeta.ChartName(), labels, annotations)
+	if len(appMeta.Config().CommonAnnotations) != 0 {
+		annotations = withCommonAnnotations(appMeta, annotations, obj.GetAnnotations())
+	}
+
+	metaStr = fmt.Sprintf(metaTemplate, apiVersion, kind, templatedName, LabelsBlock(appMeta, labels), annotations)
 	metaStr = strings.Trim(metaStr, " \n")
 	metaStr = strings.ReplaceAll(metaStr, "\n\n", "\n")
 	return metaStr, nil
 }
+
+// APIVersion - returns object apiVersion or the one pinned for object kind in config.
+func APIVersion(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) string {
+	if apiVersion, ok := appMeta.Config().APIVersions[obj.GetKind()]; ok {
+		return apiVersion
+	}
+	return obj.GetAPIVersion()
+}
+
+// LabelsBlock - returns metadata labels block with given object labels marshaled with 4 spaces indent followed by
+// chart labels helper include. The include is omitted if disabled by config; returns empty string if nothing left.
+func LabelsBlock(appMeta helmify.AppMetadata, labels string) string {
+	labels = strings.TrimRight(labels, "\n")
+	if !appMeta.Config().NoLabels {
+		labels = strings.TrimLeft(labels+"\n"+fmt.Sprintf(labelsIncludeTemplate, appMeta.ChartName()), "\n")
+	}
+	if labels == "" {
+		return ""
+	}
+	return "  labels:\n" + labels
+}
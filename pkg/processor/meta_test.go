@@ -17,3 +17,24 @@ func TestProcessObjMeta(t *testing.T) {
 	assert.Contains(t, res, "chart-name.labels")
 	assert.Contains(t, res, "chart-name.fullname")
 }
+
+func TestProcessObjMeta_NoLabels(t *testing.T) {
+	obj := internal.GenerateObj(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: my-operator-config
+  labels:
+    team: payments
+`)
+	testMeta := metadata.New(config.Config{ChartName: "chart-name", NoLabels: true})
+	testMeta.Load(obj)
+	res, err := ProcessObjMeta(testMeta, obj)
+	assert.NoError(t, err)
+	assert.NotContains(t, res, "chart-name.labels")
+	assert.Contains(t, res, "  labels:\n    team: payments")
+
+	obj.SetLabels(nil)
+	res, err = ProcessObjMeta(testMeta, obj)
+	assert.NoError(t, err)
+	assert.NotContains(t, res, "labels")
+}
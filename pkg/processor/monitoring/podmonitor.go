@@ -0,0 +1,141 @@
+package monitoring
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// metricsGuard - wraps monitoring resources to be installed only if .Values.metrics.enabled is true.
+const metricsGuard = `{{- if .Values.metrics.enabled }}
+%s
+{{- end }}`
+
+const podMonitorSelectorTempl = `  selector:
+    matchLabels:
+%[1]s
+      {{- include "%[2]s.selectorLabels" . | nindent 6 }}
+%[3]s`
+
+var podMonitorGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PodMonitor",
+}
+
+// NewPodMonitor creates processor for Prometheus operator PodMonitor resource.
+func NewPodMonitor() helmify.Processor {
+	return &podMonitor{}
+}
+
+type podMonitor struct{}
+
+// Process Prometheus operator PodMonitor object into template. Returns false if not capable of processing given resource type.
+func (p podMonitor) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != podMonitorGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get podMonitor spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no podMonitor spec presented")
+	}
+
+	// pods are selected with chart selector labels in addition to the original ones.
+	var matchLabels, matchExpr string
+	labels, _, err := unstructured.NestedStringMap(specMap, "selector", "matchLabels")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get podMonitor selector", err)
+	}
+	if len(labels) != 0 {
+		matchLabels, err = yamlformat.Marshal(labels, 6)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+	if expr, ok, _ := unstructured.NestedSlice(specMap, "selector", "matchExpressions"); ok {
+		matchExpr, err = yamlformat.Marshal(map[string]interface{}{"matchExpressions": expr}, 4)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+	delete(specMap, "selector")
+	selector := fmt.Sprintf(podMonitorSelectorTempl, matchLabels, appMeta.ChartName(), matchExpr)
+	selector = strings.ReplaceAll(strings.TrimRight(selector, " \n"), "\n\n", "\n")
+
+	err = templateNamespaceSelector(appMeta, specMap)
+	if err != nil {
+		return true, nil, err
+	}
+	spec := ""
+	if len(specMap) != 0 {
+		spec, err = yamlformat.MarshalTemplated(specMap, 2)
+		if err != nil {
+			return true, nil, err
+		}
+		spec = "\n" + spec
+	}
+
+	res := fmt.Sprintf(metricsGuard, meta+"\nspec:\n"+selector+spec)
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(res),
+		values: metricsValues(),
+	}, nil
+}
+
+// templateNamespaceSelector - replaces app namespace in namespaceSelector with release namespace.
+func templateNamespaceSelector(appMeta helmify.AppMetadata, specMap map[string]interface{}) error {
+	names, exists, err := unstructured.NestedStringSlice(specMap, "namespaceSelector", "matchNames")
+	if err != nil {
+		return fmt.Errorf("%w: unable to get namespaceSelector", err)
+	}
+	if !exists {
+		return nil
+	}
+	for i, ns := range names {
+		if ns == appMeta.Namespace() {
+			names[i] = "{{ .Release.Namespace }}"
+		}
+	}
+	return unstructured.SetNestedStringSlice(specMap, names, "namespaceSelector", "matchNames")
+}
+
+func metricsValues() helmify.Values {
+	return helmify.Values{
+		"metrics": map[string]interface{}{"enabled": true},
+	}
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
@@ -0,0 +1,59 @@
+package monitoring
+
+import (
+	"fmt"
+
+	"github.com/arttor/helmify/pkg/format"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var prometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+// NewPrometheusRule creates processor for Prometheus operator PrometheusRule resource.
+func NewPrometheusRule() helmify.Processor {
+	return &prometheusRule{}
+}
+
+type prometheusRule struct{}
+
+// Process Prometheus operator PrometheusRule object into template. Alerting and recording rules are kept as is.
+// Returns false if not capable of processing given resource type.
+func (p prometheusRule) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != prometheusRuleGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get prometheusRule spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no prometheusRule spec presented")
+	}
+	spec, err := yamlformat.Marshal(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+	// rule annotations and labels use Prometheus templating which must not be rendered by Helm.
+	spec = format.EscapeTemplates(spec)
+
+	res := fmt.Sprintf(metricsGuard, meta+"\n"+spec)
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(res),
+		values: metricsValues(),
+	}, nil
+}
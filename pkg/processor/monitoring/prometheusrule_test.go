@@ -0,0 +1,55 @@
+package monitoring
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strPrometheusRule = `apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: my-app-rules
+spec:
+  groups:
+  - name: my-app
+    rules:
+    - alert: HighErrorRate
+      expr: rate(http_requests_total{code="500"}[5m]) > 0.5
+      for: 10m
+      labels:
+        severity: critical
+      annotations:
+        summary: "{{ $labels.instance }} has high error rate"`
+
+func Test_prometheusRule_Process(t *testing.T) {
+	var testInstance prometheusRule
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strPrometheusRule)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "{{- if .Values.metrics.enabled }}\n")
+		assert.Contains(t, buf.String(), `    - alert: HighErrorRate
+      annotations:
+        summary: '{{ "{{" }} $labels.instance {{ "}}" }} has high error rate'
+      expr: rate(http_requests_total{code="500"}[5m]) > 0.5
+      for: 10m
+      labels:
+        severity: critical`)
+		assert.Contains(t, buf.String(), "\n{{- end }}")
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
@@ -0,0 +1,192 @@
+package openshift
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/pod"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var deploymentConfigGVK = schema.GroupVersionKind{
+	Group:   "apps.openshift.io",
+	Version: "v1",
+	Kind:    "DeploymentConfig",
+}
+
+var deploymentConfigTempl, _ = template.New("deploymentConfig").Parse(
+	`{{- .Meta }}
+spec:
+{{- if .Replicas }}
+{{ .Replicas }}
+{{- end }}
+  selector:
+{{ .Selector }}
+  template:
+    metadata:
+      labels:
+{{ .PodLabels }}
+{{- .PodAnnotations }}
+    spec:
+{{ .Spec }}
+{{- if .Rest }}
+{{ .Rest }}
+{{- end }}`)
+
+// NewDeploymentConfig creates processor for OpenShift DeploymentConfig resource.
+func NewDeploymentConfig() helmify.Processor {
+	return &deploymentConfig{}
+}
+
+type deploymentConfig struct{}
+
+// Process OpenShift DeploymentConfig object into template the same way as k8s Deployment.
+// Triggers and strategy are kept as is. Returns false if not capable of processing given resource type.
+func (d deploymentConfig) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != deploymentConfigGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get deploymentConfig spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no deploymentConfig spec presented")
+	}
+	values := helmify.Values{}
+
+	var replicas string
+	if r, ok, _ := unstructured.NestedInt64(specMap, "replicas"); ok {
+		replicasTpl, err := values.Add(r, nameCamel, "replicas")
+		if err != nil {
+			return true, nil, err
+		}
+		replicas = "  replicas: " + replicasTpl
+	}
+	delete(specMap, "replicas")
+
+	// DeploymentConfig selector is a plain label map unlike Deployment label selector.
+	var selector string
+	if s, _, _ := unstructured.NestedStringMap(specMap, "selector"); len(s) != 0 {
+		selector, err = yamlformat.Marshal(s, 4)
+		if err != nil {
+			return true, nil, err
+		}
+		selector += "\n"
+	}
+	selector += fmt.Sprintf(`    {{- include "%s.selectorLabels" . | nindent 4 }}`, appMeta.ChartName())
+	delete(specMap, "selector")
+
+	templateMap, _, err := unstructured.NestedMap(specMap, "template")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get deploymentConfig pod template", err)
+	}
+	podTemplate := corev1.PodTemplateSpec{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(templateMap, &podTemplate)
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to cast to pod template", err)
+	}
+	delete(specMap, "template")
+
+	var podLabels, podAnnotations string
+	if len(podTemplate.Labels) != 0 {
+		podLabels, err = yamlformat.Marshal(podTemplate.Labels, 8)
+		if err != nil {
+			return true, nil, err
+		}
+		podLabels += "\n"
+	}
+	podLabels += fmt.Sprintf(`        {{- include "%s.selectorLabels" . | nindent 8 }}`, appMeta.ChartName())
+	if len(podTemplate.Annotations) != 0 {
+		podAnnotations, err = yamlformat.Marshal(map[string]interface{}{"annotations": podTemplate.Annotations}, 6)
+		if err != nil {
+			return true, nil, err
+		}
+		podAnnotations = "\n" + podAnnotations
+	}
+
+	if err := pod.ReportDroppedFields(appMeta, obj, podTemplate.Spec, "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
+	podSpecMap, podValues, err := pod.ProcessSpec(nameCamel, appMeta, podTemplate.Spec)
+	if err != nil {
+		return true, nil, err
+	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamel, appMeta, podSpecMap, &podValues, templateMap, "spec")
+	if err != nil {
+		return true, nil, err
+	}
+	err = values.Merge(podValues)
+	if err != nil {
+		return true, nil, err
+	}
+	spec, err := yamlformat.MarshalTemplated(podSpecMap, 6)
+	if err != nil {
+		return true, nil, err
+	}
+
+	var rest string
+	if len(specMap) != 0 {
+		rest, err = yamlformat.Marshal(specMap, 2)
+		if err != nil {
+			return true, nil, err
+		}
+	}
+
+	return true, &dcResult{
+		name: name + ".yaml",
+		data: dcData{
+			Meta:           meta,
+			Replicas:       replicas,
+			Selector:       selector,
+			PodLabels:      podLabels,
+			PodAnnotations: podAnnotations,
+			Spec:           spec,
+			Rest:           rest,
+		},
+		values: values,
+	}, nil
+}
+
+type dcData struct {
+	Meta           string
+	Replicas       string
+	Selector       string
+	PodLabels      string
+	PodAnnotations string
+	Spec           string
+	Rest           string
+}
+
+type dcResult struct {
+	name   string
+	data   dcData
+	values helmify.Values
+}
+
+func (r *dcResult) Filename() string {
+	return r.name
+}
+
+func (r *dcResult) Values() helmify.Values {
+	return r.values
+}
+
+func (r *dcResult) Write(writer io.Writer) error {
+	return deploymentConfigTempl.Execute(writer, r.data)
+}
@@ -0,0 +1,64 @@
+package openshift
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strDeploymentConfig = `apiVersion: apps.openshift.io/v1
+kind: DeploymentConfig
+metadata:
+  name: frontend
+spec:
+  replicas: 2
+  selector:
+    name: frontend
+  template:
+    metadata:
+      labels:
+        name: frontend
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.25.0
+  triggers:
+  - type: ConfigChange`
+
+func Test_deploymentConfig_Process(t *testing.T) {
+	var testInstance deploymentConfig
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strDeploymentConfig)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, int64(2), tmpl.Values()["frontend"].(map[string]interface{})["replicas"])
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `spec:
+  replicas: {{ .Values.frontend.replicas }}
+  selector:
+    name: frontend
+    {{- include ".selectorLabels" . | nindent 4 }}
+  template:
+    metadata:
+      labels:
+        name: frontend
+        {{- include ".selectorLabels" . | nindent 8 }}
+    spec:
+      containers:`)
+		assert.Contains(t, buf.String(), "image: {{ .Values.frontend.web.image.repository }}")
+		assert.Contains(t, buf.String(), "  triggers:\n  - type: ConfigChange")
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
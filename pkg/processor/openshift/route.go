@@ -0,0 +1,109 @@
+package openshift
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var routeGVK = schema.GroupVersionKind{
+	Group:   "route.openshift.io",
+	Version: "v1",
+	Kind:    "Route",
+}
+
+// NewRoute creates processor for OpenShift Route resource.
+func NewRoute() helmify.Processor {
+	return &route{}
+}
+
+type route struct{}
+
+// Process OpenShift Route object into template. Returns false if not capable of processing given resource type.
+func (r route) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != routeGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get route spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no route spec presented")
+	}
+
+	values := helmify.Values{}
+	if host, ok, _ := unstructured.NestedString(specMap, "host"); ok {
+		templatedHost, err := values.Add(host, nameCamel, "host")
+		if err != nil {
+			return true, nil, err
+		}
+		specMap["host"] = templatedHost
+	}
+
+	if to, ok, _ := unstructured.NestedMap(specMap, "to"); ok {
+		templateServiceRef(appMeta, to)
+		specMap["to"] = to
+	}
+	if backends, ok, _ := unstructured.NestedSlice(specMap, "alternateBackends"); ok {
+		for _, backend := range backends {
+			if backendMap, ok := backend.(map[string]interface{}); ok {
+				templateServiceRef(appMeta, backendMap)
+			}
+		}
+		specMap["alternateBackends"] = backends
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + spec),
+		values: values,
+	}, nil
+}
+
+// templateServiceRef - replaces name of referenced Service with templated name.
+func templateServiceRef(appMeta helmify.AppMetadata, ref map[string]interface{}) {
+	if kind, _, _ := unstructured.NestedString(ref, "kind"); kind != "" && kind != "Service" {
+		return
+	}
+	if name, _, _ := unstructured.NestedString(ref, "name"); name != "" {
+		ref["name"] = appMeta.TemplatedName(name)
+	}
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
@@ -0,0 +1,71 @@
+package openshift
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	strRoute = `apiVersion: route.openshift.io/v1
+kind: Route
+metadata:
+  name: my-app-route
+  namespace: my-app
+spec:
+  host: web.apps.example.com
+  to:
+    kind: Service
+    name: my-app-web
+    weight: 100
+  port:
+    targetPort: http
+  tls:
+    termination: edge`
+	strRouteSvc = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-app-web
+  namespace: my-app
+spec:
+  ports:
+  - name: http
+    port: 80`
+)
+
+func Test_route_Process(t *testing.T) {
+	var testInstance route
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strRoute)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(strRouteSvc))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, helmify.Values{
+			"route": map[string]interface{}{"host": "web.apps.example.com"},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "host: {{ .Values.route.host | quote }}")
+		assert.Contains(t, buf.String(), `  to:
+    kind: Service
+    name: {{ include "chart.fullname" . }}-web
+    weight: 100`)
+		assert.Contains(t, buf.String(), "termination: edge")
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
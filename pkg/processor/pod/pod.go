@@ -2,10 +2,13 @@ package pod
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/arttor/helmify/pkg/cluster"
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
 	securityContext "github.com/arttor/helmify/pkg/processor/security-context"
 	"github.com/iancoleman/strcase"
 	corev1 "k8s.io/api/core/v1"
@@ -14,9 +17,23 @@ import (
 )
 
 const imagePullPolicyTemplate = "{{ .Values.%[1]s.%[2]s.imagePullPolicy }}"
+const workingDirTemplate = "{{ .Values.%[1]s.%[2]s.workingDir }}"
+const restartPolicyTemplate = "{{ .Values.%[1]s.%[2]s.restartPolicy }}"
 const envValue = "{{ quote .Values.%[1]s.%[2]s.%[3]s.%[4]s }}"
+const imageDigestTemplate = "{{ .Values.%[1]s.%[2]s.image.repository }}{{ with .Values.%[1]s.%[2]s.image.digest }}@{{ . }}{{ else }}:{{ .Values.%[1]s.%[2]s.image.tag | default .Chart.AppVersion }}{{ end }}"
+const globalImageRegistry = "{{ with .Values.global.imageRegistry }}{{ . }}/{{ end }}"
+
+// globalOrImageRegistry - global registry falling back to the registry of the original image.
+const globalOrImageRegistry = "{{ with .Values.global.imageRegistry | default .Values.%[1]s.%[2]s.image.registry }}{{ . }}/{{ end }}"
+
+// hostNetworkDNSPolicy - dnsPolicy following templated hostNetwork if no dnsPolicy is set in the source.
+const hostNetworkDNSPolicy = `{{ ternary "ClusterFirstWithHostNet" "ClusterFirst" .Values.%s.hostNetwork }}`
+
+// releaseNamespace - replaces app namespace in container command and args.
+const releaseNamespace = "{{ .Release.Namespace }}"
 
 func ProcessSpec(objName string, appMeta helmify.AppMetadata, spec corev1.PodSpec) (map[string]interface{}, helmify.Values, error) {
+	pullSecrets := append([]corev1.LocalObjectReference(nil), spec.ImagePullSecrets...)
 	values, err := processPodSpec(objName, appMeta, &spec)
 	if err != nil {
 		return nil, nil, err
@@ -33,30 +50,50 @@ func ProcessSpec(objName string, appMeta helmify.AppMetadata, spec corev1.PodSpe
 		spec.Volumes[i].PersistentVolumeClaim.ClaimName = tempPVCName
 	}
 
+	// reference prefixed class names if enabled
+	if appMeta.Config().PrefixClassNames {
+		if spec.PriorityClassName != "" {
+			spec.PriorityClassName = appMeta.TemplatedName(spec.PriorityClassName)
+		}
+		if spec.RuntimeClassName != nil {
+			runtimeClassName := appMeta.TemplatedName(*spec.RuntimeClassName)
+			spec.RuntimeClassName = &runtimeClassName
+		}
+	}
+
 	// replace container resources with template to values.
 	specMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
 	if err != nil {
 		return nil, nil, fmt.Errorf("%w: unable to convert podSpec to map", err)
 	}
 
-	specMap, values, err = processNestedContainers(specMap, objName, values, "containers")
+	specMap, values, err = processNestedContainers(specMap, objName, appMeta, values, "containers")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	specMap, values, err = processNestedContainers(specMap, objName, appMeta, values, "initContainers")
 	if err != nil {
 		return nil, nil, err
 	}
 
-	specMap, values, err = processNestedContainers(specMap, objName, values, "initContainers")
+	specMap, values, err = processNestedContainers(specMap, objName, appMeta, values, "ephemeralContainers")
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if appMeta.Config().ImagePullSecrets {
-		if _, defined := specMap["imagePullSecrets"]; !defined {
-			specMap["imagePullSecrets"] = "{{ .Values.imagePullSecrets | default list | toJson }}"
-			values["imagePullSecrets"] = []string{}
+		specMap["imagePullSecrets"] = imagePullSecrets(appMeta, pullSecrets)
+		values["imagePullSecrets"] = []string{}
+		if appMeta.Config().GlobalValues {
+			err = unstructured.SetNestedStringSlice(values, []string{}, "global", "imagePullSecrets")
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: unable to set global image pull secrets value", err)
+			}
 		}
 	}
 
-	err = securityContext.ProcessContainerSecurityContext(objName, specMap, &values)
+	err = securityContext.ProcessContainerSecurityContext(objName, appMeta, specMap, &values)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -73,17 +110,244 @@ func ProcessSpec(objName string, appMeta helmify.AppMetadata, spec corev1.PodSpe
 		}
 	}
 
+	err = processPodFlags(objName, spec, specMap, &values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// runtime class is overridable unless it references prefixed class of the chart. Overhead is kept as is.
+	if spec.RuntimeClassName != nil && !appMeta.Config().PrefixClassNames {
+		tpl, err := values.Add(*spec.RuntimeClassName, objName, "runtimeClassName")
+		if err != nil {
+			return nil, nil, err
+		}
+		specMap["runtimeClassName"] = tpl
+	}
+
+	err = processVolumeSources(objName, specMap, &values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if appMeta.Config().ContainerPortValues {
+		for _, containerType := range []string{"containers", "initContainers"} {
+			err = processContainerPorts(objName, appMeta, containerType, specMap, &values)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
 	return specMap, values, nil
 }
 
-func processNestedContainers(specMap map[string]interface{}, objName string, values map[string]interface{}, containerKey string) (map[string]interface{}, map[string]interface{}, error) {
+// processPodFlags - lifts dnsPolicy, enableServiceLinks, automountServiceAccountToken and enabled hostNetwork,
+// hostPID and hostIPC to values if set. Pods with hostNetwork and no dnsPolicy get dnsPolicy depending on
+// the hostNetwork value, as host network pods need ClusterFirstWithHostNet to resolve cluster names.
+func processPodFlags(objName string, spec corev1.PodSpec, specMap map[string]interface{}, values *helmify.Values) error {
+	fields := map[string]interface{}{}
+	if spec.HostNetwork {
+		fields["hostNetwork"] = true
+		if spec.DNSPolicy == "" {
+			specMap["dnsPolicy"] = fmt.Sprintf(hostNetworkDNSPolicy, objName)
+		}
+	}
+	if spec.HostPID {
+		fields["hostPID"] = true
+	}
+	if spec.HostIPC {
+		fields["hostIPC"] = true
+	}
+	if spec.DNSPolicy != "" {
+		fields["dnsPolicy"] = string(spec.DNSPolicy)
+	}
+	if spec.EnableServiceLinks != nil {
+		fields["enableServiceLinks"] = *spec.EnableServiceLinks
+	}
+	if spec.AutomountServiceAccountToken != nil {
+		fields["automountServiceAccountToken"] = *spec.AutomountServiceAccountToken
+	}
+	for field, value := range fields {
+		tpl, err := values.Add(value, objName, field)
+		if err != nil {
+			return err
+		}
+		specMap[field] = tpl
+	}
+	return nil
+}
+
+// initRestartPolicyRe - matches restartPolicy of init containers, see ProcessInitContainerRestartPolicy.
+var initRestartPolicyRe = regexp.MustCompile(`^initContainers\[\d+\]\.restartPolicy$`)
+
+// ReportDroppedFields - reports fields of source pod spec found in object by given path which are dropped by
+// conversion to typed spec, e.g. fields unknown to the used k8s API version. See processor.ReportLossy.
+func ReportDroppedFields(appMeta helmify.AppMetadata, obj *unstructured.Unstructured, spec corev1.PodSpec, path ...string) error {
+	source, _, err := unstructured.NestedMap(obj.Object, path...)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get pod spec", err)
+	}
+	typed, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return fmt.Errorf("%w: unable to convert podSpec to map", err)
+	}
+	var dropped []string
+	for _, field := range processor.DroppedFields(source, typed) {
+		if !initRestartPolicyRe.MatchString(field) {
+			dropped = append(dropped, field)
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+	return processor.ReportLossy(appMeta, obj, "pod spec fields dropped: "+strings.Join(dropped, ", "))
+}
+
+// ProcessInitContainerRestartPolicy - lifts restartPolicy of init containers, e.g. Always of native sidecars, from
+// source pod spec found in object by given path to .Values.<objName>.<container>.restartPolicy and sets it to
+// processed specMap. The field is missing in corev1.Container of used k8s API version, so it is dropped by
+// ProcessSpec working with typed pod spec.
+func ProcessInitContainerRestartPolicy(objName string, appMeta helmify.AppMetadata, specMap map[string]interface{}, values *helmify.Values, obj map[string]interface{}, path ...string) error {
+	source, _, err := unstructured.NestedSlice(obj, append(path, "initContainers")...)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get init containers", err)
+	}
+	policies := map[string]string{}
+	for _, c := range source {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(container, "name")
+		if policy, ok, _ := unstructured.NestedString(container, "restartPolicy"); ok && policy != "" {
+			policies[name] = policy
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+	containers, _, err := unstructured.NestedSlice(specMap, "initContainers")
+	if err != nil {
+		return fmt.Errorf("%w: unable to get init containers", err)
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(container, "name")
+		policy, ok := policies[name]
+		if !ok {
+			continue
+		}
+		containerName := processor.ContainerValuesName(appMeta.Config(), "initContainers", name)
+		err = unstructured.SetNestedField(*values, policy, processor.ContainerValuesPath(objName, containerName, "restartPolicy")...)
+		if err != nil {
+			return fmt.Errorf("%w: unable to set init container restartPolicy", err)
+		}
+		container["restartPolicy"] = fmt.Sprintf(restartPolicyTemplate, objName, containerName)
+	}
+	return unstructured.SetNestedSlice(specMap, containers, "initContainers")
+}
+
+// processContainerPorts - lifts container ports to .Values.<objName>.<container>.ports.<portName> integer values.
+// Port names are camel cased in values, unnamed ports are lifted by index, e.g. port0.
+func processContainerPorts(objName string, appMeta helmify.AppMetadata, containerType string, specMap map[string]interface{}, values *helmify.Values) error {
+	containers, _, err := unstructured.NestedSlice(specMap, containerType)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get pod %s", err, containerType)
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(container, "name")
+		containerName := processor.ContainerValuesName(appMeta.Config(), containerType, name)
+		ports, _, err := unstructured.NestedSlice(container, "ports")
+		if err != nil {
+			return fmt.Errorf("%w: unable to get container %s ports", err, name)
+		}
+		for i, p := range ports {
+			port, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerPort, ok, _ := unstructured.NestedInt64(port, "containerPort")
+			if !ok {
+				continue
+			}
+			portName, _, _ := unstructured.NestedString(port, "name")
+			key := portName
+			if key == "" {
+				key = "port" + strconv.Itoa(i)
+			}
+			tpl, err := values.Add(containerPort, processor.ContainerValuesPath(objName, containerName, "ports", key)...)
+			if err != nil {
+				return err
+			}
+			port["containerPort"] = tpl
+		}
+		if len(ports) != 0 {
+			err = unstructured.SetNestedSlice(container, ports, "ports")
+			if err != nil {
+				return fmt.Errorf("%w: unable to template container %s ports", err, name)
+			}
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	return unstructured.SetNestedSlice(specMap, containers, containerType)
+}
+
+// processVolumeSources - lifts emptyDir medium and sizeLimit and hostPath path and type of pod volumes to
+// .Values.<objName>.volumes.<volumeName> if set in the source.
+func processVolumeSources(objName string, specMap map[string]interface{}, values *helmify.Values) error {
+	volumes, _, err := unstructured.NestedSlice(specMap, "volumes")
+	if err != nil {
+		return fmt.Errorf("%w: unable to get pod volumes", err)
+	}
+	for _, v := range volumes {
+		volume, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(volume, "name")
+		for source, fields := range map[string][]string{
+			"emptyDir": {"medium", "sizeLimit"},
+			"hostPath": {"path", "type"},
+		} {
+			for _, field := range fields {
+				value, ok, _ := unstructured.NestedString(volume, source, field)
+				if !ok || value == "" {
+					continue
+				}
+				tpl, err := values.Add(value, objName, "volumes", name, source, field)
+				if err != nil {
+					return err
+				}
+				err = unstructured.SetNestedField(volume, tpl, source, field)
+				if err != nil {
+					return fmt.Errorf("%w: unable to template volume %s", err, name)
+				}
+			}
+		}
+	}
+	if len(volumes) == 0 {
+		return nil
+	}
+	return unstructured.SetNestedSlice(specMap, volumes, "volumes")
+}
+
+func processNestedContainers(specMap map[string]interface{}, objName string, appMeta helmify.AppMetadata, values map[string]interface{}, containerKey string) (map[string]interface{}, map[string]interface{}, error) {
 	containers, _, err := unstructured.NestedSlice(specMap, containerKey)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	if len(containers) > 0 {
-		containers, values, err = processContainers(objName, values, containerKey, containers)
+		containers, values, err = processContainers(objName, appMeta, values, containerKey, containers)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -97,10 +361,10 @@ func processNestedContainers(specMap map[string]interface{}, objName string, val
 	return specMap, values, nil
 }
 
-func processContainers(objName string, values helmify.Values, containerType string, containers []interface{}) ([]interface{}, helmify.Values, error) {
+func processContainers(objName string, appMeta helmify.AppMetadata, values helmify.Values, containerType string, containers []interface{}) ([]interface{}, helmify.Values, error) {
 	for i := range containers {
-		containerName := strcase.ToLowerCamel((containers[i].(map[string]interface{})["name"]).(string))
-		res, exists, err := unstructured.NestedMap(values, objName, containerName, "resources")
+		containerName := processor.ContainerValuesName(appMeta.Config(), containerType, (containers[i].(map[string]interface{})["name"]).(string))
+		res, exists, err := unstructured.NestedMap(values, processor.ContainerValuesPath(objName, containerName, "resources")...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -111,17 +375,41 @@ func processContainers(objName string, values helmify.Values, containerType stri
 			}
 		}
 
-		args, exists, err := unstructured.NestedStringSlice(containers[i].(map[string]interface{}), "args")
+		// lift container entrypoint into values to make it overridable.
+		for _, field := range []string{"command", "args"} {
+			arr, exists, err := unstructured.NestedStringSlice(containers[i].(map[string]interface{}), field)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !exists || len(arr) == 0 {
+				continue
+			}
+			tpl := `{{- toYaml .Values.%[1]s.%[2]s.%[3]s | nindent 8 }}`
+			if containsRelease(arr) {
+				// release namespace reference in values is rendered with tpl
+				tpl = `{{- tpl (toYaml .Values.%[1]s.%[2]s.%[3]s) . | nindent 8 }}`
+			}
+			err = unstructured.SetNestedField(containers[i].(map[string]interface{}), fmt.Sprintf(tpl, objName, containerName, field), field)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			err = unstructured.SetNestedStringSlice(values, arr, processor.ContainerValuesPath(objName, containerName, field)...)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: unable to set deployment value field", err)
+			}
+		}
+
+		lifecycle, exists, err := unstructured.NestedMap(containers[i].(map[string]interface{}), "lifecycle")
 		if err != nil {
 			return nil, nil, err
 		}
-		if exists && len(args) > 0 {
-			err = unstructured.SetNestedField(containers[i].(map[string]interface{}), fmt.Sprintf(`{{- toYaml .Values.%[1]s.%[2]s.args | nindent 8 }}`, objName, containerName), "args")
+		if exists && len(lifecycle) > 0 {
+			err = unstructured.SetNestedField(containers[i].(map[string]interface{}), fmt.Sprintf(`{{- toYaml .Values.%s.%s.lifecycle | nindent 10 }}`, objName, containerName), "lifecycle")
 			if err != nil {
 				return nil, nil, err
 			}
-
-			err = unstructured.SetNestedStringSlice(values, args, objName, containerName, "args")
+			err = unstructured.SetNestedMap(values, lifecycle, processor.ContainerValuesPath(objName, containerName, "lifecycle")...)
 			if err != nil {
 				return nil, nil, fmt.Errorf("%w: unable to set deployment value field", err)
 			}
@@ -130,10 +418,19 @@ func processContainers(objName string, values helmify.Values, containerType stri
 	return containers, values, nil
 }
 
+func containsRelease(args []string) bool {
+	for _, arg := range args {
+		if strings.Contains(arg, releaseNamespace) {
+			return true
+		}
+	}
+	return false
+}
+
 func processPodSpec(name string, appMeta helmify.AppMetadata, pod *corev1.PodSpec) (helmify.Values, error) {
 	values := helmify.Values{}
 	for i, c := range pod.Containers {
-		processed, err := processPodContainer(name, appMeta, c, &values)
+		processed, err := processPodContainer(name, processor.ContainerValuesName(appMeta.Config(), "containers", c.Name), appMeta, c, &values)
 		if err != nil {
 			return nil, err
 		}
@@ -141,13 +438,21 @@ func processPodSpec(name string, appMeta helmify.AppMetadata, pod *corev1.PodSpe
 	}
 
 	for i, c := range pod.InitContainers {
-		processed, err := processPodContainer(name, appMeta, c, &values)
+		processed, err := processPodContainer(name, processor.ContainerValuesName(appMeta.Config(), "initContainers", c.Name), appMeta, c, &values)
 		if err != nil {
 			return nil, err
 		}
 		pod.InitContainers[i] = processed
 	}
 
+	for i, c := range pod.EphemeralContainers {
+		processed, err := processPodContainer(name, processor.ContainerValuesName(appMeta.Config(), "ephemeralContainers", c.Name), appMeta, corev1.Container(c.EphemeralContainerCommon), &values)
+		if err != nil {
+			return nil, err
+		}
+		pod.EphemeralContainers[i].EphemeralContainerCommon = corev1.EphemeralContainerCommon(processed)
+	}
+
 	for _, v := range pod.Volumes {
 		if v.ConfigMap != nil {
 			v.ConfigMap.Name = appMeta.TemplatedName(v.ConfigMap.Name)
@@ -155,6 +460,17 @@ func processPodSpec(name string, appMeta helmify.AppMetadata, pod *corev1.PodSpe
 		if v.Secret != nil {
 			v.Secret.SecretName = appMeta.TemplatedName(v.Secret.SecretName)
 		}
+		if v.Projected != nil {
+			// downwardAPI and serviceAccountToken sources do not reference other objects
+			for _, src := range v.Projected.Sources {
+				if src.ConfigMap != nil {
+					src.ConfigMap.Name = appMeta.TemplatedName(src.ConfigMap.Name)
+				}
+				if src.Secret != nil {
+					src.Secret.Name = appMeta.TemplatedName(src.Secret.Name)
+				}
+			}
+		}
 	}
 	pod.ServiceAccountName = appMeta.TemplatedName(pod.ServiceAccountName)
 
@@ -165,25 +481,51 @@ func processPodSpec(name string, appMeta helmify.AppMetadata, pod *corev1.PodSpe
 	return values, nil
 }
 
-func processPodContainer(name string, appMeta helmify.AppMetadata, c corev1.Container, values *helmify.Values) (corev1.Container, error) {
-	index := strings.LastIndex(c.Image, ":")
-	if index < 0 {
+func processPodContainer(name, containerName string, appMeta helmify.AppMetadata, c corev1.Container, values *helmify.Values) (corev1.Container, error) {
+	dropContainerDefaults(&c)
+	c.Command = namespaceArgs(c.Command, appMeta.Namespace())
+	c.Args = namespaceArgs(c.Args, appMeta.Namespace())
+	repo, tag, digest := splitImage(c.Image)
+	if tag == "" && digest == "" {
 		return c, fmt.Errorf("wrong image format: %q", c.Image)
 	}
-	repo, tag := c.Image[:index], c.Image[index+1:]
-	containerName := strcase.ToLowerCamel(c.Name)
 	c.Image = fmt.Sprintf("{{ .Values.%[1]s.%[2]s.image.repository }}:{{ .Values.%[1]s.%[2]s.image.tag | default .Chart.AppVersion }}", name, containerName)
+	if digest != "" {
+		// digest is preferred over tag. Tag is used if digest is overridden with empty value.
+		c.Image = fmt.Sprintf(imageDigestTemplate, name, containerName)
+		err := unstructured.SetNestedField(*values, digest, processor.ContainerValuesPath(name, containerName, "image", "digest")...)
+		if err != nil {
+			return c, fmt.Errorf("%w: unable to set deployment value field", err)
+		}
+	}
+	if appMeta.Config().GlobalImageRegistry || appMeta.Config().GlobalValues {
+		var registry string
+		registry, repo = splitRegistry(repo)
+		if registry == "" {
+			c.Image = globalImageRegistry + c.Image
+		} else {
+			c.Image = fmt.Sprintf(globalOrImageRegistry, name, containerName) + c.Image
+			err := unstructured.SetNestedField(*values, registry, processor.ContainerValuesPath(name, containerName, "image", "registry")...)
+			if err != nil {
+				return c, fmt.Errorf("%w: unable to set deployment value field", err)
+			}
+		}
+		err := unstructured.SetNestedField(*values, "", "global", "imageRegistry")
+		if err != nil {
+			return c, fmt.Errorf("%w: unable to set global image registry value", err)
+		}
+	}
 
-	err := unstructured.SetNestedField(*values, repo, name, containerName, "image", "repository")
+	err := unstructured.SetNestedField(*values, repo, processor.ContainerValuesPath(name, containerName, "image", "repository")...)
 	if err != nil {
 		return c, fmt.Errorf("%w: unable to set deployment value field", err)
 	}
-	err = unstructured.SetNestedField(*values, tag, name, containerName, "image", "tag")
+	err = unstructured.SetNestedField(*values, tag, processor.ContainerValuesPath(name, containerName, "image", "tag")...)
 	if err != nil {
 		return c, fmt.Errorf("%w: unable to set deployment value field", err)
 	}
 
-	c, err = processEnv(name, appMeta, c, values)
+	c, err = processEnv(name, containerName, appMeta, c, values)
 	if err != nil {
 		return c, err
 	}
@@ -201,30 +543,111 @@ func processPodContainer(name string, appMeta helmify.AppMetadata, c corev1.Cont
 		Value: fmt.Sprintf("{{ quote .Values.%s }}", cluster.DomainKey),
 	})
 	for k, v := range c.Resources.Requests {
-		err = unstructured.SetNestedField(*values, v.ToUnstructured(), name, containerName, "resources", "requests", k.String())
+		err = unstructured.SetNestedField(*values, v.ToUnstructured(), processor.ContainerValuesPath(name, containerName, "resources", "requests", k.String())...)
 		if err != nil {
 			return c, fmt.Errorf("%w: unable to set container resources value", err)
 		}
 	}
 	for k, v := range c.Resources.Limits {
-		err = unstructured.SetNestedField(*values, v.ToUnstructured(), name, containerName, "resources", "limits", k.String())
+		err = unstructured.SetNestedField(*values, v.ToUnstructured(), processor.ContainerValuesPath(name, containerName, "resources", "limits", k.String())...)
 		if err != nil {
 			return c, fmt.Errorf("%w: unable to set container resources value", err)
 		}
 	}
 
 	if c.ImagePullPolicy != "" {
-		err = unstructured.SetNestedField(*values, string(c.ImagePullPolicy), name, containerName, "imagePullPolicy")
+		err = unstructured.SetNestedField(*values, string(c.ImagePullPolicy), processor.ContainerValuesPath(name, containerName, "imagePullPolicy")...)
 		if err != nil {
 			return c, fmt.Errorf("%w: unable to set container imagePullPolicy", err)
 		}
 		c.ImagePullPolicy = corev1.PullPolicy(fmt.Sprintf(imagePullPolicyTemplate, name, containerName))
 	}
+	if c.WorkingDir != "" {
+		err = unstructured.SetNestedField(*values, c.WorkingDir, processor.ContainerValuesPath(name, containerName, "workingDir")...)
+		if err != nil {
+			return c, fmt.Errorf("%w: unable to set container workingDir", err)
+		}
+		c.WorkingDir = fmt.Sprintf(workingDirTemplate, name, containerName)
+	}
 	return c, nil
 }
 
-func processEnv(name string, appMeta helmify.AppMetadata, c corev1.Container, values *helmify.Values) (corev1.Container, error) {
-	containerName := strcase.ToLowerCamel(c.Name)
+// dropContainerDefaults - removes container fields equal to Kubernetes defaults, e.g. set by API server in manifests
+// exported from a cluster, to keep templates clean. Disabled stdin and tty are omitted on conversion already.
+func dropContainerDefaults(c *corev1.Container) {
+	if c.TerminationMessagePath == corev1.TerminationMessagePathDefault {
+		c.TerminationMessagePath = ""
+	}
+	if c.TerminationMessagePolicy == corev1.TerminationMessageReadFile {
+		c.TerminationMessagePolicy = ""
+	}
+}
+
+// namespaceArgs - returns container arguments with app namespace replaced by release namespace. Only exact matches
+// are replaced: the whole argument, e.g. 'my-ns', or flag value, e.g. '--namespace=my-ns'.
+func namespaceArgs(args []string, ns string) []string {
+	if ns == "" || len(args) == 0 {
+		return args
+	}
+	res := make([]string, len(args))
+	for i, arg := range args {
+		res[i] = arg
+		if arg == ns {
+			res[i] = releaseNamespace
+			continue
+		}
+		if flag, value, ok := strings.Cut(arg, "="); ok && value == ns && strings.HasPrefix(flag, "-") {
+			res[i] = flag + "=" + releaseNamespace
+		}
+	}
+	return res
+}
+
+// imagePullSecrets - returns template merging given pod image pull secrets with chart-wide imagePullSecrets value
+// using imagePullSecrets helper. Names of chart secrets are prefixed with chart fullname.
+func imagePullSecrets(appMeta helmify.AppMetadata, secrets []corev1.LocalObjectReference) string {
+	args := `(dict "root" .)`
+	if len(secrets) != 0 {
+		items := make([]string, len(secrets))
+		for i, s := range secrets {
+			name := strconv.Quote(s.Name)
+			if appMeta.TemplatedName(s.Name) != s.Name {
+				name = fmt.Sprintf(`(printf "%%s-%%s" (include "%s.fullname" .) %q)`, appMeta.ChartName(), appMeta.TrimName(s.Name))
+			}
+			items[i] = fmt.Sprintf(`(dict "name" %s)`, name)
+		}
+		args = fmt.Sprintf(`(dict "root" . "secrets" (list %s))`, strings.Join(items, " "))
+	}
+	return fmt.Sprintf(`{{ include "%s.imagePullSecrets" %s }}`, appMeta.ChartName(), args)
+}
+
+// splitImage - splits image reference 'repository[:tag][@digest]' to its parts.
+func splitImage(image string) (repo, tag, digest string) {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image, digest = image[:i], image[i+1:]
+	}
+	// colon before the last slash belongs to registry host port
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image, tag = image[:i], image[i+1:]
+	}
+	return image, tag, digest
+}
+
+// splitRegistry - splits image repository 'registry/path' to its parts. Like docker, the first path component is
+// treated as a registry host only if it contains '.' or ':' or is 'localhost'.
+func splitRegistry(repo string) (registry, path string) {
+	i := strings.Index(repo, "/")
+	if i < 0 {
+		return "", repo
+	}
+	host := repo[:i]
+	if !strings.ContainsAny(host, ".:") && host != "localhost" {
+		return "", repo
+	}
+	return host, repo[i+1:]
+}
+
+func processEnv(name, containerName string, appMeta helmify.AppMetadata, c corev1.Container, values *helmify.Values) (corev1.Container, error) {
 	for i := 0; i < len(c.Env); i++ {
 		if c.Env[i].ValueFrom != nil {
 			switch {
@@ -237,8 +660,12 @@ func processEnv(name string, appMeta helmify.AppMetadata, c corev1.Container, va
 			}
 			continue
 		}
+		if ns := appMeta.Namespace(); ns != "" && c.Env[i].Value == ns {
+			c.Env[i].Value = "{{ .Release.Namespace | quote }}"
+			continue
+		}
 
-		err := unstructured.SetNestedField(*values, c.Env[i].Value, name, containerName, "env", strcase.ToLowerCamel(strings.ToLower(c.Env[i].Name)))
+		err := unstructured.SetNestedField(*values, c.Env[i].Value, processor.ContainerValuesPath(name, containerName, "env", strcase.ToLowerCamel(strings.ToLower(c.Env[i].Name)))...)
 		if err != nil {
 			return c, fmt.Errorf("%w: unable to set deployment value field", err)
 		}
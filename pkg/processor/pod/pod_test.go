@@ -3,9 +3,13 @@ package pod
 import (
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
 	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/metadata"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/arttor/helmify/internal"
@@ -40,6 +44,66 @@ spec:
         - containerPort: 80
 `
 
+	strDeploymentWithCommandAndArgs = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx-deployment
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.14.2
+        command:
+        - nginx
+        args:
+        - -g
+        - daemon off;
+`
+
+	strDeploymentWithLifecycle = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: nginx
+spec:
+  template:
+    spec:
+      containers:
+      - name: nginx
+        image: nginx:1.14.2
+        lifecycle:
+          preStop:
+            exec:
+              command: ["/bin/sh", "-c", "sleep 15"]
+          postStart:
+            httpGet:
+              path: /warmup
+              port: 8080
+`
+
+	strDeploymentWithPorts = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dns
+spec:
+  template:
+    spec:
+      containers:
+      - name: dns
+        image: coredns:1.11.1
+        ports:
+        - name: dns
+          containerPort: 53
+          protocol: UDP
+        - name: metrics
+          containerPort: 9153
+          hostPort: 9153
+          hostIP: 127.0.0.1
+`
+
 	strDeploymentWithNoArgs = `
 apiVersion: apps/v1
 kind: Deployment
@@ -63,6 +127,65 @@ spec:
         ports:
         - containerPort: 80
 `
+
+	strDeploymentWithProjectedVolume = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app-web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.14.2
+      volumes:
+      - name: all-in-one
+        projected:
+          sources:
+          - configMap:
+              name: my-app-config
+          - downwardAPI:
+              items:
+              - path: labels
+                fieldRef:
+                  fieldPath: metadata.labels
+`
+
+	strDeploymentWithResourceFieldRef = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.14.2
+        env:
+        - name: GOMAXPROCS
+          valueFrom:
+            resourceFieldRef:
+              containerName: web
+              resource: limits.cpu
+              divisor: "1"
+        resources:
+          limits:
+            cpu: "2"
+`
 )
 
 func Test_pod_Process(t *testing.T) {
@@ -110,6 +233,61 @@ func Test_pod_Process(t *testing.T) {
 		}, tmpl)
 	})
 
+	t.Run("deployment with command and args", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeploymentWithCommandAndArgs)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+		specMap, values, err := ProcessSpec("nginx", &metadata.Service{}, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{- toYaml .Values.nginx.nginx.command | nindent 8 }}", container["command"])
+		assert.Equal(t, "{{- toYaml .Values.nginx.nginx.args | nindent 8 }}", container["args"])
+		assert.NotContains(t, container, "lifecycle")
+
+		containerValues := values["nginx"].(map[string]interface{})["nginx"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"nginx"}, containerValues["command"])
+		assert.Equal(t, []interface{}{"-g", "daemon off;"}, containerValues["args"])
+	})
+
+	t.Run("container with lifecycle hooks", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeploymentWithLifecycle)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+		specMap, values, err := ProcessSpec("nginx", &metadata.Service{}, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{- toYaml .Values.nginx.nginx.lifecycle | nindent 10 }}", container["lifecycle"])
+
+		containerValues := values["nginx"].(map[string]interface{})["nginx"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"preStop": map[string]interface{}{
+				"exec": map[string]interface{}{"command": []interface{}{"/bin/sh", "-c", "sleep 15"}},
+			},
+			"postStart": map[string]interface{}{
+				"httpGet": map[string]interface{}{"path": "/warmup", "port": int64(8080)},
+			},
+		}, containerValues["lifecycle"])
+	})
+
+	t.Run("container ports with protocol and hostPort", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeploymentWithPorts)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+		specMap, _, err := ProcessSpec("dns", &metadata.Service{}, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "dns", "containerPort": int64(53), "protocol": "UDP"},
+			map[string]interface{}{"name": "metrics", "containerPort": int64(9153), "hostPort": int64(9153), "hostIP": "127.0.0.1"},
+		}, container["ports"])
+	})
+
 	t.Run("deployment with no args", func(t *testing.T) {
 		var deploy appsv1.Deployment
 		obj := internal.GenerateObj(strDeploymentWithNoArgs)
@@ -149,4 +327,327 @@ func Test_pod_Process(t *testing.T) {
 		}, tmpl)
 	})
 
+	t.Run("projected volume sources", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeploymentWithProjectedVolume)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: my-app-config"))
+
+		specMap, _, err := ProcessSpec("web", appMeta, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		sources, _, err := unstructured.NestedSlice(specMap["volumes"].([]interface{})[0].(map[string]interface{}), "projected", "sources")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": `{{ include "chart.fullname" . }}-config`}, sources[0].(map[string]interface{})["configMap"])
+		assert.Equal(t, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"path":     "labels",
+					"fieldRef": map[string]interface{}{"fieldPath": "metadata.labels"},
+				},
+			},
+		}, sources[1].(map[string]interface{})["downwardAPI"])
+	})
+
+	t.Run("env from resourceFieldRef kept with lifted resources", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeploymentWithResourceFieldRef)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+
+		specMap, values, err := ProcessSpec("web", &metadata.Service{}, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{- toYaml .Values.web.web.resources | nindent 10 }}", container["resources"])
+		assert.Equal(t, map[string]interface{}{
+			"name": "GOMAXPROCS",
+			"valueFrom": map[string]interface{}{
+				"resourceFieldRef": map[string]interface{}{
+					"containerName": "web",
+					"resource":      "limits.cpu",
+					"divisor":       "1",
+				},
+			},
+		}, container["env"].([]interface{})[0])
+		limits, _, err := unstructured.NestedMap(values, "web", "web", "resources", "limits")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"cpu": "2"}, limits)
+	})
+
+	t.Run("global image registry", func(t *testing.T) {
+		var deploy appsv1.Deployment
+		obj := internal.GenerateObj(strDeployment)
+		err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &deploy)
+		assert.NoError(t, err)
+		deploy.Spec.Template.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox:1.36"}}
+
+		appMeta := metadata.New(config.Config{GlobalImageRegistry: true})
+		specMap, values, err := ProcessSpec("nginx", appMeta, deploy.Spec.Template.Spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{ with .Values.global.imageRegistry }}{{ . }}/{{ end }}{{ .Values.nginx.nginx.image.repository }}:{{ .Values.nginx.nginx.image.tag | default .Chart.AppVersion }}", container["image"])
+		initContainer := specMap["initContainers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{ with .Values.global.imageRegistry }}{{ . }}/{{ end }}{{ .Values.nginx.init.image.repository }}:{{ .Values.nginx.init.image.tag | default .Chart.AppVersion }}", initContainer["image"])
+		assert.Equal(t, map[string]interface{}{"imageRegistry": ""}, values["global"])
+	})
+	t.Run("global image registry replaces explicit registry", func(t *testing.T) {
+		spec := corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "web", Image: "docker.io/library/nginx:1.25"},
+			{Name: "app", Image: "myreg.io:5000/team/app:1.0"},
+			{Name: "sidecar", Image: "team/sidecar:1.0"},
+		}}
+
+		appMeta := metadata.New(config.Config{GlobalImageRegistry: true})
+		specMap, values, err := ProcessSpec("nginx", appMeta, spec)
+		assert.NoError(t, err)
+
+		containers := specMap["containers"].([]interface{})
+		assert.Equal(t, "{{ with .Values.global.imageRegistry | default .Values.nginx.web.image.registry }}{{ . }}/{{ end }}{{ .Values.nginx.web.image.repository }}:{{ .Values.nginx.web.image.tag | default .Chart.AppVersion }}", containers[0].(map[string]interface{})["image"])
+		assert.Equal(t, "{{ with .Values.global.imageRegistry }}{{ . }}/{{ end }}{{ .Values.nginx.sidecar.image.repository }}:{{ .Values.nginx.sidecar.image.tag | default .Chart.AppVersion }}", containers[2].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"registry": "docker.io", "repository": "library/nginx", "tag": "1.25"}, values["nginx"].(map[string]interface{})["web"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"registry": "myreg.io:5000", "repository": "team/app", "tag": "1.0"}, values["nginx"].(map[string]interface{})["app"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"repository": "team/sidecar", "tag": "1.0"}, values["nginx"].(map[string]interface{})["sidecar"].(map[string]interface{})["image"])
+	})
+	t.Run("init and ephemeral container values nested", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "app:1.0.0"}, {Name: "init-foo", Image: "foo:1.0.0"}},
+			InitContainers: []corev1.Container{{Name: "app", Image: "migrate:2.0.0", Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			}}, {Name: "foo", Image: "foo:2.0.0"}},
+			EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox:1.36"},
+			}},
+		}
+		appMeta := metadata.New(config.Config{NestContainerValues: true})
+		specMap, values, err := ProcessSpec("app", appMeta, spec)
+		assert.NoError(t, err)
+
+		initContainer := specMap["initContainers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{ .Values.app.initContainers.app.image.repository }}:{{ .Values.app.initContainers.app.image.tag | default .Chart.AppVersion }}", initContainer["image"])
+		assert.Equal(t, "{{- toYaml .Values.app.initContainers.app.resources | nindent 10 }}", initContainer["resources"])
+		ephemeralContainer := specMap["ephemeralContainers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{ .Values.app.ephemeralContainers.debug.image.repository }}:{{ .Values.app.ephemeralContainers.debug.image.tag | default .Chart.AppVersion }}", ephemeralContainer["image"])
+
+		appValues := values["app"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"repository": "app", "tag": "1.0.0"}, appValues["app"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"repository": "foo", "tag": "1.0.0"}, appValues["initFoo"].(map[string]interface{})["image"])
+		initValues := appValues["initContainers"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"repository": "migrate", "tag": "2.0.0"}, initValues["app"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"limits": map[string]interface{}{"cpu": "100m"}}, initValues["app"].(map[string]interface{})["resources"])
+		assert.Equal(t, map[string]interface{}{"repository": "foo", "tag": "2.0.0"}, initValues["foo"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{"repository": "busybox", "tag": "1.36"}, appValues["ephemeralContainers"].(map[string]interface{})["debug"].(map[string]interface{})["image"])
+	})
+	t.Run("image with digest", func(t *testing.T) {
+		spec := corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "pinned", Image: "ghcr.io/org/app@sha256:4f5e"},
+			{Name: "both", Image: "registry.local:5000/app:1.2.3@sha256:9a8b"},
+		}}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		containers := specMap["containers"].([]interface{})
+		assert.Equal(t, "{{ .Values.app.pinned.image.repository }}{{ with .Values.app.pinned.image.digest }}@{{ . }}{{ else }}:{{ .Values.app.pinned.image.tag | default .Chart.AppVersion }}{{ end }}",
+			containers[0].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{
+			"repository": "ghcr.io/org/app",
+			"tag":        "",
+			"digest":     "sha256:4f5e",
+		}, values["app"].(map[string]interface{})["pinned"].(map[string]interface{})["image"])
+		assert.Equal(t, map[string]interface{}{
+			"repository": "registry.local:5000/app",
+			"tag":        "1.2.3",
+			"digest":     "sha256:9a8b",
+		}, values["app"].(map[string]interface{})["both"].(map[string]interface{})["image"])
+	})
+	t.Run("dns policy, service links and token automount", func(t *testing.T) {
+		automount, serviceLinks := false, false
+		spec := corev1.PodSpec{
+			Containers:                   []corev1.Container{{Name: "app", Image: "app:1.0.0"}},
+			DNSPolicy:                    corev1.DNSClusterFirstWithHostNet,
+			EnableServiceLinks:           &serviceLinks,
+			AutomountServiceAccountToken: &automount,
+		}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "{{ .Values.app.dnsPolicy | quote }}", specMap["dnsPolicy"])
+		assert.Equal(t, "{{ .Values.app.enableServiceLinks }}", specMap["enableServiceLinks"])
+		assert.Equal(t, "{{ .Values.app.automountServiceAccountToken }}", specMap["automountServiceAccountToken"])
+		appValues := values["app"].(map[string]interface{})
+		assert.Equal(t, "ClusterFirstWithHostNet", appValues["dnsPolicy"])
+		assert.Equal(t, false, appValues["enableServiceLinks"])
+		assert.Equal(t, false, appValues["automountServiceAccountToken"])
+	})
+	t.Run("host network with dns policy", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Containers:  []corev1.Container{{Name: "app", Image: "app:1.0.0"}},
+			HostNetwork: true,
+			HostIPC:     true,
+			DNSPolicy:   corev1.DNSDefault,
+		}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "{{ .Values.app.hostNetwork }}", specMap["hostNetwork"])
+		assert.Equal(t, "{{ .Values.app.hostIPC }}", specMap["hostIPC"])
+		assert.Equal(t, "{{ .Values.app.dnsPolicy | quote }}", specMap["dnsPolicy"], "source dnsPolicy kept")
+		appValues := values["app"].(map[string]interface{})
+		assert.Equal(t, true, appValues["hostNetwork"])
+		assert.Equal(t, true, appValues["hostIPC"])
+		assert.Equal(t, "Default", appValues["dnsPolicy"])
+		assert.NotContains(t, appValues, "hostPID")
+	})
+	t.Run("emptyDir and hostPath volumes", func(t *testing.T) {
+		sizeLimit := resource.MustParse("256Mi")
+		hostPathType := corev1.HostPathDirectoryOrCreate
+		spec := corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: "app:1.0.0"}},
+			Volumes: []corev1.Volume{
+				{Name: "cache-volume", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+					Medium: corev1.StorageMediumMemory, SizeLimit: &sizeLimit,
+				}}},
+				{Name: "tmp", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+				{Name: "logs", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{
+					Path: "/var/log", Type: &hostPathType,
+				}}},
+			},
+		}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		volumes := specMap["volumes"].([]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"medium":    "{{ .Values.app.volumes.cacheVolume.emptyDir.medium | quote }}",
+			"sizeLimit": "{{ .Values.app.volumes.cacheVolume.emptyDir.sizeLimit | quote }}",
+		}, volumes[0].(map[string]interface{})["emptyDir"])
+		assert.Equal(t, map[string]interface{}{}, volumes[1].(map[string]interface{})["emptyDir"], "unset fields omitted")
+		assert.Equal(t, map[string]interface{}{
+			"path": "{{ .Values.app.volumes.logs.hostPath.path | quote }}",
+			"type": "{{ .Values.app.volumes.logs.hostPath.type | quote }}",
+		}, volumes[2].(map[string]interface{})["hostPath"])
+		assert.Equal(t, map[string]interface{}{
+			"cacheVolume": map[string]interface{}{"emptyDir": map[string]interface{}{"medium": "Memory", "sizeLimit": "256Mi"}},
+			"logs":        map[string]interface{}{"hostPath": map[string]interface{}{"path": "/var/log", "type": "DirectoryOrCreate"}},
+		}, values["app"].(map[string]interface{})["volumes"])
+	})
+	t.Run("dns policy, service links and token automount omitted", func(t *testing.T) {
+		spec := corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "app:1.0.0"}}}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		for _, field := range []string{"dnsPolicy", "enableServiceLinks", "automountServiceAccountToken"} {
+			assert.NotContains(t, specMap, field)
+			assert.NotContains(t, values["app"], field)
+		}
+	})
+	t.Run("default termination message fields dropped", func(t *testing.T) {
+		spec := corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:                     "app",
+				Image:                    "app:1.0.0",
+				TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+				TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+			}},
+			InitContainers: []corev1.Container{{
+				Name:                     "init",
+				Image:                    "init:1.0.0",
+				TerminationMessagePath:   "/tmp/message",
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				Stdin:                    true,
+				TTY:                      true,
+			}},
+		}
+		specMap, _, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		for _, field := range []string{"terminationMessagePath", "terminationMessagePolicy", "stdin", "tty"} {
+			assert.NotContains(t, container, field)
+		}
+		initContainer := specMap["initContainers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "/tmp/message", initContainer["terminationMessagePath"])
+		assert.Equal(t, "FallbackToLogsOnError", initContainer["terminationMessagePolicy"])
+		assert.Equal(t, true, initContainer["stdin"])
+		assert.Equal(t, true, initContainer["tty"])
+	})
+	t.Run("namespace in args and env replaced with release namespace", func(t *testing.T) {
+		appMeta := metadata.New(config.Config{ChartName: "foo"})
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: foo-controller\n  namespace: foo-system"))
+		spec := corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "manager",
+			Image: "manager:1.0.0",
+			Args:  []string{"--leader-election-namespace=foo-system", "--watch-namespace=foo-system-extra", "foo-system"},
+			Env: []corev1.EnvVar{
+				{Name: "WATCH_NAMESPACE", Value: "foo-system"},
+				{Name: "PEER", Value: "foo-system.svc"},
+			},
+		}}}
+		specMap, values, err := ProcessSpec("manager", appMeta, spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, "{{- tpl (toYaml .Values.manager.manager.args) . | nindent 8 }}", container["args"])
+		containerValues := values["manager"].(map[string]interface{})["manager"].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			"--leader-election-namespace={{ .Release.Namespace }}", "--watch-namespace=foo-system-extra", "{{ .Release.Namespace }}",
+		}, containerValues["args"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "WATCH_NAMESPACE", "value": "{{ .Release.Namespace | quote }}"},
+			map[string]interface{}{"name": "PEER", "value": "{{ quote .Values.manager.manager.env.peer }}"},
+			map[string]interface{}{"name": "KUBERNETES_CLUSTER_DOMAIN", "value": "{{ quote .Values.kubernetesClusterDomain }}"},
+		}, container["env"])
+		assert.Equal(t, map[string]interface{}{"peer": "foo-system.svc"}, containerValues["env"])
+	})
+	t.Run("container port values", func(t *testing.T) {
+		appMeta := metadata.New(config.Config{ChartName: "chart", ContainerPortValues: true})
+		spec := corev1.PodSpec{Containers: []corev1.Container{{
+			Name:  "web",
+			Image: "nginx:1.25",
+			Ports: []corev1.ContainerPort{
+				{Name: "http", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+				{Name: "http-metrics", ContainerPort: 9090},
+				{ContainerPort: 8443},
+			},
+		}}}
+		specMap, values, err := ProcessSpec("web", appMeta, spec)
+		assert.NoError(t, err)
+
+		container := specMap["containers"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "http", "containerPort": "{{ .Values.web.web.ports.http }}", "protocol": "TCP"},
+			map[string]interface{}{"name": "http-metrics", "containerPort": "{{ .Values.web.web.ports.httpMetrics }}"},
+			map[string]interface{}{"containerPort": "{{ .Values.web.web.ports.port2 }}"},
+		}, container["ports"])
+		containerValues := values["web"].(map[string]interface{})["web"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{
+			"http":        int64(8080),
+			"httpMetrics": int64(9090),
+			"port2":       int64(8443),
+		}, containerValues["ports"])
+	})
+	t.Run("runtime class and overhead", func(t *testing.T) {
+		runtimeClass := "gvisor"
+		spec := corev1.PodSpec{
+			RuntimeClassName: &runtimeClass,
+			Overhead:         corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			Containers:       []corev1.Container{{Name: "app", Image: "app:1.0.0"}},
+		}
+		specMap, values, err := ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+
+		assert.Equal(t, "{{ .Values.app.runtimeClassName | quote }}", specMap["runtimeClassName"])
+		assert.Equal(t, "gvisor", values["app"].(map[string]interface{})["runtimeClassName"])
+		assert.Equal(t, map[string]interface{}{"cpu": "250m"}, specMap["overhead"])
+
+		spec.RuntimeClassName = nil
+		specMap, values, err = ProcessSpec("app", &metadata.Service{}, spec)
+		assert.NoError(t, err)
+		assert.NotContains(t, specMap, "runtimeClassName")
+		assert.NotContains(t, values["app"], "runtimeClassName")
+	})
 }
@@ -0,0 +1,100 @@
+package podsecurity
+
+import (
+	"fmt"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	pspGroup = "policy"
+	pspKind  = "PodSecurityPolicy"
+
+	levelPrivileged = "privileged"
+	levelBaseline   = "baseline"
+	levelRestricted = "restricted"
+)
+
+// baselineCapabilities - capabilities allowed to be added by the baseline Pod Security Standard.
+var baselineCapabilities = map[string]bool{
+	"AUDIT_WRITE": true, "CHOWN": true, "DAC_OVERRIDE": true, "FOWNER": true, "FSETID": true, "KILL": true,
+	"MKNOD": true, "NET_BIND_SERVICE": true, "SETFCAP": true, "SETGID": true, "SETPCAP": true, "SETUID": true,
+	"SYS_CHROOT": true,
+}
+
+// restrictedVolumes - volume types allowed by the restricted Pod Security Standard.
+var restrictedVolumes = map[string]bool{
+	"configMap": true, "csi": true, "downwardAPI": true, "emptyDir": true, "ephemeral": true,
+	"persistentVolumeClaim": true, "projected": true, "secret": true,
+}
+
+// NewPodSecurityPolicy creates processor for deprecated PodSecurityPolicy resource.
+func NewPodSecurityPolicy() helmify.Processor {
+	return &psp{}
+}
+
+type psp struct{}
+
+// Process PodSecurityPolicy object. PodSecurityPolicy is removed in Kubernetes 1.25, so the object is dropped with
+// a warning suggesting Pod Security Standard namespace label instead. With config.KeepPSP the object is left to
+// the default processor. Returns false if not capable of processing given resource type.
+func (p psp) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind().Group != pspGroup || obj.GetKind() != pspKind || appMeta.Config().KeepPSP {
+		return false, nil, nil
+	}
+	reason := fmt.Sprintf("PodSecurityPolicy is removed in Kubernetes 1.25: dropped. "+
+		"Use Pod Security Admission instead, e.g. label the release namespace with pod-security.kubernetes.io/enforce=%s", level(obj))
+	return true, nil, processor.ReportLossy(appMeta, obj, reason)
+}
+
+// level - returns the most restrictive Pod Security Standard level allowing pods admitted by given policy.
+func level(obj *unstructured.Unstructured) string {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	for _, field := range []string{"privileged", "hostNetwork", "hostPID", "hostIPC"} {
+		if enabled, _, _ := unstructured.NestedBool(spec, field); enabled {
+			return levelPrivileged
+		}
+	}
+	if ports, _, _ := unstructured.NestedSlice(spec, "hostPorts"); len(ports) != 0 {
+		return levelPrivileged
+	}
+	capabilities, _, _ := unstructured.NestedStringSlice(spec, "allowedCapabilities")
+	for _, c := range capabilities {
+		if !baselineCapabilities[c] {
+			return levelPrivileged
+		}
+	}
+	volumes, _, _ := unstructured.NestedStringSlice(spec, "volumes")
+	restricted := len(volumes) != 0
+	for _, v := range volumes {
+		if v == "*" || v == "hostPath" {
+			return levelPrivileged
+		}
+		restricted = restricted && restrictedVolumes[v]
+	}
+
+	escalation, set, _ := unstructured.NestedBool(spec, "allowPrivilegeEscalation")
+	restricted = restricted && set && !escalation
+	runAsUser, _, _ := unstructured.NestedString(spec, "runAsUser", "rule")
+	restricted = restricted && runAsUser == "MustRunAsNonRoot"
+	drop, _, _ := unstructured.NestedStringSlice(spec, "requiredDropCapabilities")
+	restricted = restricted && contains(drop, "ALL")
+	for _, c := range capabilities {
+		restricted = restricted && c == "NET_BIND_SERVICE"
+	}
+	if restricted {
+		return levelRestricted
+	}
+	return levelBaseline
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
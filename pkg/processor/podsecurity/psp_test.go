@@ -0,0 +1,100 @@
+package podsecurity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const pspYaml = `apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: my-app-restricted
+spec:
+  privileged: false
+  allowPrivilegeEscalation: false
+  requiredDropCapabilities:
+  - ALL
+  volumes:
+  - configMap
+  - secret
+  - emptyDir
+  runAsUser:
+    rule: MustRunAsNonRoot
+  seLinux:
+    rule: RunAsAny
+  supplementalGroups:
+    rule: RunAsAny
+  fsGroup:
+    rule: RunAsAny`
+
+const privilegedPspYaml = `apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: my-app-privileged
+spec:
+  privileged: true
+  hostNetwork: true
+  volumes:
+  - '*'`
+
+func Test_psp_Process(t *testing.T) {
+	var testInstance psp
+
+	t.Run("dropped with warning", func(t *testing.T) {
+		hook := test.NewGlobal()
+		defer hook.Reset()
+		obj := internal.GenerateObj(pspYaml)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Nil(t, tmpl)
+		if assert.NotNil(t, hook.LastEntry()) {
+			assert.Equal(t, logrus.WarnLevel, hook.LastEntry().Level)
+			assert.Contains(t, hook.LastEntry().Message, "pod-security.kubernetes.io/enforce=restricted")
+		}
+	})
+	t.Run("strict mode fails", func(t *testing.T) {
+		obj := internal.GenerateObj(privilegedPspYaml)
+		processed, _, err := testInstance.Process(metadata.New(config.Config{Strict: true}), obj)
+		assert.Equal(t, true, processed)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "pod-security.kubernetes.io/enforce=privileged")
+		}
+	})
+	t.Run("kept", func(t *testing.T) {
+		obj := internal.GenerateObj(pspYaml)
+		processed, _, err := testInstance.Process(metadata.New(config.Config{KeepPSP: true}), obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
+
+func Test_level(t *testing.T) {
+	assert.Equal(t, levelRestricted, level(internal.GenerateObj(pspYaml)))
+	assert.Equal(t, levelPrivileged, level(internal.GenerateObj(privilegedPspYaml)))
+	assert.Equal(t, levelBaseline, level(internal.GenerateObj(`apiVersion: policy/v1beta1
+kind: PodSecurityPolicy
+metadata:
+  name: my-app-baseline
+spec:
+  allowedCapabilities:
+  - NET_BIND_SERVICE
+  - CHOWN
+  volumes:
+  - configMap`)))
+}
@@ -1,8 +1,10 @@
 package rbac
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/metadata"
 
 	"github.com/arttor/helmify/internal"
@@ -15,6 +17,13 @@ metadata:
   name: my-operator-controller-manager
   namespace: my-operator-system`
 
+const serviceAccountIRSAYaml = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: my-operator-controller-manager
+  annotations:
+    eks.amazonaws.com/role-arn: arn:aws:iam::111122223333:role/my-operator-dev`
+
 func Test_serviceAccount_Process(t *testing.T) {
 	var testInstance serviceAccount
 
@@ -24,6 +33,25 @@ func Test_serviceAccount_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("cloud identity annotation lifted to values", func(t *testing.T) {
+		obj := internal.GenerateObj(serviceAccountIRSAYaml)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"myOperatorControllerManager": map[string]interface{}{
+				"serviceAccount": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"eks.amazonaws.com/role-arn": "arn:aws:iam::111122223333:role/my-operator-dev",
+					},
+				},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "{{- toYaml .Values.myOperatorControllerManager.serviceAccount.annotations | nindent 4 }}")
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
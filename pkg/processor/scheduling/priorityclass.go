@@ -0,0 +1,99 @@
+package scheduling
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var priorityClassGVK = schema.GroupVersionKind{
+	Group:   "scheduling.k8s.io",
+	Version: "v1",
+	Kind:    "PriorityClass",
+}
+
+// NewPriorityClass creates processor for k8s PriorityClass resource.
+func NewPriorityClass() helmify.Processor {
+	return &priorityClass{}
+}
+
+type priorityClass struct{}
+
+// Process k8s PriorityClass object into template. Returns false if not capable of processing given resource type.
+func (p priorityClass) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != priorityClassGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj, metaOpts(appMeta)...)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	values := helmify.Values{}
+	body := bodyOf(obj)
+	if value, ok, _ := unstructured.NestedInt64(body, "value"); ok {
+		templatedValue, err := values.Add(value, nameCamel, "value")
+		if err != nil {
+			return true, nil, err
+		}
+		body["value"] = templatedValue
+	}
+
+	res, err := yamlformat.MarshalTemplated(body, 0)
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to marshal priorityClass", err)
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + res),
+		values: values,
+	}, nil
+}
+
+// metaOpts - keeps original names of cluster-wide classes unless prefixing is enabled in config.
+func metaOpts(appMeta helmify.AppMetadata) []processor.MetaOpt {
+	if appMeta.Config().PrefixClassNames {
+		return nil
+	}
+	return []processor.MetaOpt{processor.WithOriginalName()}
+}
+
+// bodyOf - returns object fields except apiVersion, kind and metadata.
+func bodyOf(obj *unstructured.Unstructured) map[string]interface{} {
+	body := make(map[string]interface{}, len(obj.Object))
+	for k, v := range obj.Object {
+		if k == "apiVersion" || k == "kind" || k == "metadata" {
+			continue
+		}
+		body[k] = v
+	}
+	return body
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
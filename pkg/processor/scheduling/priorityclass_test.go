@@ -0,0 +1,59 @@
+package scheduling
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strPriorityClass = `apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: high-priority
+value: 1000000
+globalDefault: false
+preemptionPolicy: Never
+description: "For critical pods only."`
+
+func Test_priorityClass_Process(t *testing.T) {
+	var testInstance priorityClass
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strPriorityClass)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, helmify.Values{
+			"highPriority": map[string]interface{}{"value": int64(1000000)},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "name: high-priority\n")
+		assert.Contains(t, buf.String(), "value: {{ .Values.highPriority.value }}")
+		assert.Contains(t, buf.String(), "preemptionPolicy: Never")
+		assert.Contains(t, buf.String(), "description: For critical pods only.")
+	})
+	t.Run("prefixed name", func(t *testing.T) {
+		obj := internal.GenerateObj(strPriorityClass)
+		appMeta := metadata.New(config.Config{ChartName: "chart", PrefixClassNames: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `name: {{ include "chart.fullname" . }}-high-priority`)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
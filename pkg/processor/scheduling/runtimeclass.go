@@ -0,0 +1,48 @@
+package scheduling
+
+import (
+	"fmt"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var runtimeClassGVK = schema.GroupVersionKind{
+	Group:   "node.k8s.io",
+	Version: "v1",
+	Kind:    "RuntimeClass",
+}
+
+// NewRuntimeClass creates processor for k8s RuntimeClass resource.
+func NewRuntimeClass() helmify.Processor {
+	return &runtimeClass{}
+}
+
+type runtimeClass struct{}
+
+// Process k8s RuntimeClass object into template. Handler, overhead and scheduling are kept as is.
+// Returns false if not capable of processing given resource type.
+func (r runtimeClass) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != runtimeClassGVK {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj, metaOpts(appMeta)...)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+
+	res, err := yamlformat.Marshal(bodyOf(obj), 0)
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to marshal runtimeClass", err)
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + res),
+		values: helmify.Values{},
+	}, nil
+}
@@ -1,11 +1,14 @@
 package secret
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/arttor/helmify/pkg/format"
 	"io"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode/utf8"
 
 	"github.com/arttor/helmify/pkg/processor"
 
@@ -30,6 +33,15 @@ var secretTempl, _ = template.New("secret").Parse(
 {{ .Type }}
 {{- end }}`)
 
+// autoGenerateAnnotation - annotation of source Secret with comma-separated data keys generated once on install and
+// kept on upgrades: existing Secret is looked up in the cluster. The annotation is removed from the chart.
+const autoGenerateAnnotation = "helmify.io/auto-generate"
+
+const (
+	lookupTemplate    = `{{- $existing := (lookup "v1" "Secret" .Release.Namespace %s).data | default dict }}`
+	generatedTemplate = `{{ dig %q (randAlphaNum 16 | b64enc) $existing }}`
+)
+
 var configMapGVC = schema.GroupVersionKind{
 	Group:   "",
 	Version: "v1",
@@ -53,6 +65,7 @@ func (d secret) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructu
 	if err != nil {
 		return true, nil, fmt.Errorf("%w: unable to cast to secret", err)
 	}
+	generated := generatedKeys(obj)
 	meta, err := processor.ProcessObjMeta(appMeta, obj)
 	if err != nil {
 		return true, nil, err
@@ -72,11 +85,26 @@ func (d secret) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructu
 	values := helmify.Values{}
 	var data, stringData string
 	templatedData := map[string]string{}
-	for key := range sec.Data {
+	decode := appMeta.Config().DecodeSecrets && (sec.Type == "" || sec.Type == corev1.SecretTypeOpaque)
+	for key := range generated {
+		templatedData[key] = fmt.Sprintf(generatedTemplate, key)
+	}
+	for key, value := range sec.Data {
+		if generated[key] {
+			continue
+		}
 		keyCamelCase := strcase.ToLowerCamel(key)
 		if key == strings.ToUpper(key) {
 			keyCamelCase = strcase.ToLowerCamel(strings.ToLower(key))
 		}
+		if decode && isText(value) {
+			templatedName, err := values.AddSecretPlaintext(string(value), nameCamelCase, keyCamelCase)
+			if err != nil {
+				return true, nil, fmt.Errorf("%w: unable add secret to values", err)
+			}
+			templatedData[key] = templatedName
+			continue
+		}
 		templatedName, err := values.AddSecret(true, nameCamelCase, keyCamelCase)
 		if err != nil {
 			return true, nil, fmt.Errorf("%w: unable add secret to values", err)
@@ -91,6 +119,9 @@ func (d secret) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructu
 		data = strings.ReplaceAll(data, "'", "")
 		data = format.FixUnterminatedQuotes(data)
 	}
+	if len(generated) != 0 {
+		data = fmt.Sprintf(lookupTemplate, nameExpr(appMeta.TemplatedName(obj.GetName()))) + "\n" + data
+	}
 
 	templatedData = map[string]string{}
 	for key := range sec.StringData {
@@ -125,6 +156,41 @@ func (d secret) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructu
 	}, nil
 }
 
+// generatedKeys - returns data keys listed in autoGenerateAnnotation and removes the annotation from given object.
+// Returns nil if the annotation is not set.
+func generatedKeys(obj *unstructured.Unstructured) map[string]bool {
+	annotations := obj.GetAnnotations()
+	keys, ok := annotations[autoGenerateAnnotation]
+	if !ok {
+		return nil
+	}
+	delete(annotations, autoGenerateAnnotation)
+	obj.SetAnnotations(annotations)
+	res := map[string]bool{}
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			res[key] = true
+		}
+	}
+	return res
+}
+
+// nameExpr - returns template expression of given templated name for use in template actions, e.g.
+// (printf "%s-db" (include "chart.fullname" .)) for {{ include "chart.fullname" . }}-db.
+func nameExpr(templatedName string) string {
+	end := strings.Index(templatedName, "}}")
+	if !strings.HasPrefix(templatedName, "{{") || end == -1 {
+		return strconv.Quote(templatedName)
+	}
+	action := strings.TrimSpace(templatedName[2:end])
+	return fmt.Sprintf("(printf %s (%s))", strconv.Quote("%s"+templatedName[end+2:]), action)
+}
+
+// isText - checks if decoded secret value is a text config rather than binary data.
+func isText(value []byte) bool {
+	return utf8.Valid(value) && !bytes.ContainsRune(value, 0)
+}
+
 type result struct {
 	name string
 	data struct {
@@ -1,8 +1,11 @@
 package secret
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/metadata"
 
 	"github.com/arttor/helmify/internal"
@@ -21,6 +24,28 @@ metadata:
   namespace: my-operator-system
 type: opaque`
 
+// config.yaml: "log: debug\n", bin: 0x00 0xff
+const secretConfigYaml = `apiVersion: v1
+data:
+  config.yaml: bG9nOiBkZWJ1Zwo=
+  bin: AP8=
+kind: Secret
+metadata:
+  name: app-config
+  namespace: my-operator-system
+type: Opaque`
+
+const secretTLSYaml = `apiVersion: v1
+data:
+  tls.crt: Y3J0
+  tls.key: a2V5
+  ca.crt: Y2E=
+kind: Secret
+metadata:
+  name: my-operator-tls
+  namespace: my-operator-system
+type: kubernetes.io/tls`
+
 func Test_secret_Process(t *testing.T) {
 	var testInstance secret
 
@@ -30,6 +55,66 @@ func Test_secret_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("decode secrets", func(t *testing.T) {
+		obj := internal.GenerateObj(secretConfigYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart", DecodeSecrets: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"appConfig": map[string]interface{}{
+				"configYaml": "log: debug\n",
+				"bin":        "",
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "config.yaml: {{ .Values.appConfig.configYaml | b64enc | quote }}")
+		assert.Contains(t, buf.String(), `bin: {{ required "appConfig.bin is required" .Values.appConfig.bin`)
+	})
+	t.Run("tls bundle", func(t *testing.T) {
+		obj := internal.GenerateObj(secretTLSYaml)
+		// certificates are not decoded as defaults even with decode secrets, they are set per environment
+		appMeta := metadata.New(config.Config{ChartName: "chart", DecodeSecrets: true})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"myOperatorTls": map[string]interface{}{"tlsCrt": "", "tlsKey": "", "caCrt": ""},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `tls.crt: {{ required "myOperatorTls.tlsCrt is required" .Values.myOperatorTls.tlsCrt`)
+		assert.Contains(t, buf.String(), `tls.key: {{ required "myOperatorTls.tlsKey is required" .Values.myOperatorTls.tlsKey`)
+		assert.Contains(t, buf.String(), `ca.crt: {{ required "myOperatorTls.caCrt is required" .Values.myOperatorTls.caCrt`)
+	})
+	t.Run("auto-generated keys", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: v1
+kind: Secret
+metadata:
+  name: my-app-db
+  annotations:
+    helmify.io/auto-generate: password, token
+data:
+  user: YWRtaW4=
+  password: cXdlcnR5`)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `{{- $existing := (lookup "v1" "Secret" .Release.Namespace (printf "%s-my-app-db" (include "chart.fullname" .))).data | default dict }}
+data:
+  password: {{ dig "password" (randAlphaNum 16 | b64enc) $existing }}
+  token: {{ dig "token" (randAlphaNum 16 | b64enc) $existing }}
+  user: `)
+		assert.NotContains(t, buf.String(), "helmify.io/auto-generate")
+		assert.Equal(t, helmify.Values{"myAppDb": map[string]interface{}{"user": ""}}, tmpl.Values())
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
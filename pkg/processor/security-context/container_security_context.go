@@ -4,7 +4,7 @@ import (
 	"fmt"
 
 	"github.com/arttor/helmify/pkg/helmify"
-	"github.com/iancoleman/strcase"
+	"github.com/arttor/helmify/pkg/processor"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -15,13 +15,18 @@ const (
 )
 
 // ProcessContainerSecurityContext adds 'securityContext' to the podSpec in specMap, if it doesn't have one already defined.
-func ProcessContainerSecurityContext(nameCamel string, specMap map[string]interface{}, values *helmify.Values) error {
-	err := processSecurityContext(nameCamel, "containers", specMap, values)
+func ProcessContainerSecurityContext(nameCamel string, appMeta helmify.AppMetadata, specMap map[string]interface{}, values *helmify.Values) error {
+	err := processSecurityContext(nameCamel, appMeta, "containers", specMap, values)
 	if err != nil {
 		return err
 	}
 
-	err = processSecurityContext(nameCamel, "initContainers", specMap, values)
+	err = processSecurityContext(nameCamel, appMeta, "initContainers", specMap, values)
+	if err != nil {
+		return err
+	}
+
+	err = processSecurityContext(nameCamel, appMeta, "ephemeralContainers", specMap, values)
 	if err != nil {
 		return err
 	}
@@ -29,11 +34,11 @@ func ProcessContainerSecurityContext(nameCamel string, specMap map[string]interf
 	return nil
 }
 
-func processSecurityContext(nameCamel string, containerType string, specMap map[string]interface{}, values *helmify.Values) error {
+func processSecurityContext(nameCamel string, appMeta helmify.AppMetadata, containerType string, specMap map[string]interface{}, values *helmify.Values) error {
 	if containers, defined := specMap[containerType]; defined {
 		for _, container := range containers.([]interface{}) {
 			castedContainer := container.(map[string]interface{})
-			containerName := strcase.ToLowerCamel(castedContainer["name"].(string))
+			containerName := processor.ContainerValuesName(appMeta.Config(), containerType, castedContainer["name"].(string))
 			if _, defined2 := castedContainer["securityContext"]; defined2 {
 				err := setSecContextValue(nameCamel, containerName, castedContainer, values)
 				if err != nil {
@@ -51,7 +56,7 @@ func processSecurityContext(nameCamel string, containerType string, specMap map[
 
 func setSecContextValue(resourceName string, containerName string, castedContainer map[string]interface{}, values *helmify.Values) error {
 	if castedContainer["securityContext"] != nil {
-		err := unstructured.SetNestedField(*values, castedContainer["securityContext"], resourceName, containerName, cscValueName)
+		err := unstructured.SetNestedField(*values, castedContainer["securityContext"], processor.ContainerValuesPath(resourceName, containerName, cscValueName)...)
 		if err != nil {
 			return err
 		}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,7 +94,7 @@ func TestProcessContainerSecurityContext(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			ProcessContainerSecurityContext(tt.args.nameCamel, tt.args.specMap, tt.args.values)
+			ProcessContainerSecurityContext(tt.args.nameCamel, &metadata.Service{}, tt.args.specMap, tt.args.values)
 			assert.Equal(t, tt.want, tt.args.values)
 		})
 	}
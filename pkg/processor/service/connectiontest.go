@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// defaultTestImage - image of Helm test containers, lifted to values to be overridden for air-gapped installs.
+const defaultTestImage = "busybox:1.36"
+
+const connectionTestTempl = `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ include "%[1]s.fullname" . }}-test-connection
+%[2]s
+  annotations:
+    helm.sh/hook: test
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+spec:
+  restartPolicy: Never
+  containers:
+%[3]s`
+
+const connectionTestContainerTempl = `  - name: %[1]s
+    image: {{ .Values.tests.image }}
+    command: ['nc', '-z', '-w', '5', '%[2]s', '{{ (index .Values.%[3]s.ports %[4]d).port }}']`
+
+// ConnectionTest - returns Helm test Pod checking TCP connectivity to the first TCP port of every given Service
+// loaded to app metadata. Returns nil if none of the Services has TCP ports.
+func ConnectionTest(appMeta helmify.AppMetadata, services []string) helmify.Template {
+	var containers []string
+	for _, svc := range services {
+		ports, ok := appMeta.ServicePorts(svc)
+		if !ok {
+			continue
+		}
+		for i, p := range ports {
+			if p.Protocol != "" && p.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+			containers = append(containers, fmt.Sprintf(connectionTestContainerTempl,
+				serviceName(appMeta, svc), appMeta.TemplatedName(svc), ValuesName(appMeta, svc), i))
+			break
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	data := fmt.Sprintf(connectionTestTempl, appMeta.ChartName(), processor.LabelsBlock(appMeta, ""), strings.Join(containers, "\n"))
+	data = strings.ReplaceAll(data, "\n\n", "\n")
+	return &result{
+		name:   "tests/test-connection",
+		data:   data,
+		values: helmify.Values{"tests": map[string]interface{}{"image": defaultTestImage}},
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const connectionTestServices = `apiVersion: v1
+kind: Service
+metadata:
+  name: myapp-web
+  namespace: myapp
+spec:
+  ports:
+  - name: dns
+    port: 53
+    protocol: UDP
+  - name: http
+    port: 80
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: myapp-metrics
+  namespace: myapp
+spec:
+  ports:
+  - name: metrics
+    port: 56
+    protocol: UDP`
+
+func TestConnectionTest(t *testing.T) {
+	appMeta := metadata.New(config.Config{ChartName: "chart"})
+	for _, doc := range strings.Split(connectionTestServices, "---\n") {
+		appMeta.Load(internal.GenerateObj(doc))
+	}
+
+	tmpl := ConnectionTest(appMeta, []string{"myapp-web", "myapp-metrics", "external"})
+	assert.Equal(t, "tests/test-connection.yaml", tmpl.Filename())
+	assert.Equal(t, helmify.Values{"tests": map[string]interface{}{"image": "busybox:1.36"}}, tmpl.Values())
+
+	var buf bytes.Buffer
+	assert.NoError(t, tmpl.Write(&buf))
+	assert.Equal(t, `apiVersion: v1
+kind: Pod
+metadata:
+  name: {{ include "chart.fullname" . }}-test-connection
+  labels:
+  {{- include "chart.labels" . | nindent 4 }}
+  annotations:
+    helm.sh/hook: test
+    helm.sh/hook-delete-policy: before-hook-creation,hook-succeeded
+spec:
+  restartPolicy: Never
+  containers:
+  - name: web
+    image: {{ .Values.tests.image }}
+    command: ['nc', '-z', '-w', '5', '{{ include "chart.fullname" . }}-web', '{{ (index .Values.web.ports 1).port }}']`, buf.String())
+
+	assert.Nil(t, ConnectionTest(appMeta, []string{"myapp-metrics"}), "no TCP ports")
+}
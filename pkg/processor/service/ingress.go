@@ -5,6 +5,7 @@ import (
 	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/processor"
 	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
 	"io"
 	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -30,7 +31,7 @@ func NewIngress() helmify.Processor {
 
 type ingress struct{}
 
-// Process k8s Service object into template. Returns false if not capable of processing given resource type.
+// Process k8s Ingress object into template. Returns false if not capable of processing given resource type.
 func (r ingress) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
 	if obj.GroupVersionKind() != ingressGVC {
 		return false, nil, nil
@@ -40,13 +41,21 @@ func (r ingress) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstruct
 	if err != nil {
 		return true, nil, fmt.Errorf("%w: unable to cast to ingress", err)
 	}
-	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	values := helmify.Values{}
+	meta, err := processor.ProcessObjMeta(appMeta, obj, processor.WithAnnotations(values))
 	if err != nil {
 		return true, nil, err
 	}
 	name := appMeta.TrimName(obj.GetName())
 	processIngressSpec(appMeta, &ing.Spec)
-	spec, err := yamlformat.Marshal(map[string]interface{}{"spec": &ing.Spec}, 0)
+	if ing.Spec.IngressClassName != nil {
+		className, err := values.Add(*ing.Spec.IngressClassName, strcase.ToLowerCamel(name), "ingress", "className")
+		if err != nil {
+			return true, nil, err
+		}
+		ing.Spec.IngressClassName = &className
+	}
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": &ing.Spec}, 0)
 	if err != nil {
 		return true, nil, err
 	}
@@ -57,6 +66,7 @@ func (r ingress) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstruct
 			Meta string
 			Spec string
 		}{Meta: meta, Spec: spec},
+		values: values,
 	}, nil
 }
 
@@ -64,6 +74,12 @@ func processIngressSpec(appMeta helmify.AppMetadata, ing *networkingv1.IngressSp
 	if ing.DefaultBackend != nil && ing.DefaultBackend.Service != nil {
 		ing.DefaultBackend.Service.Name = appMeta.TemplatedName(ing.DefaultBackend.Service.Name)
 	}
+	// rewire TLS secrets generated by the chart, external secret names are kept as is
+	for i := range ing.TLS {
+		if ing.TLS[i].SecretName != "" {
+			ing.TLS[i].SecretName = appMeta.TemplatedName(ing.TLS[i].SecretName)
+		}
+	}
 	for i := range ing.Rules {
 		if ing.Rules[i].IngressRuleValue.HTTP != nil {
 			for j := range ing.Rules[i].IngressRuleValue.HTTP.Paths {
@@ -81,6 +97,7 @@ type ingressResult struct {
 		Meta string
 		Spec string
 	}
+	values helmify.Values
 }
 
 func (r *ingressResult) Filename() string {
@@ -88,7 +105,7 @@ func (r *ingressResult) Filename() string {
 }
 
 func (r *ingressResult) Values() helmify.Values {
-	return helmify.Values{}
+	return r.values
 }
 
 func (r *ingressResult) Write(writer io.Writer) error {
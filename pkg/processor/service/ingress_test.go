@@ -1,8 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+
 	"github.com/arttor/helmify/pkg/metadata"
 
 	"github.com/arttor/helmify/internal"
@@ -27,6 +31,72 @@ spec:
                 port:
                   number: 8443`
 
+const ingressNginxYaml = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: myapp-ingress
+  annotations:
+    nginx.ingress.kubernetes.io/rewrite-target: /
+    nginx.ingress.kubernetes.io/ssl-redirect: "false"
+spec:
+  ingressClassName: nginx
+  rules:
+    - http:
+        paths:
+          - path: /testpath
+            pathType: Prefix
+            backend:
+              service:
+                name: myapp-service
+                port:
+                  number: 8443`
+
+const ingressDefaultBackendYaml = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: myapp-ingress
+spec:
+  defaultBackend:
+    service:
+      name: myapp-service
+      port:
+        name: https`
+
+const ingressTLSYaml = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: myapp-ingress
+spec:
+  tls:
+    - hosts:
+        - myapp.example.com
+      secretName: myapp-tls
+    - hosts:
+        - other.example.com
+      secretName: external-tls
+  defaultBackend:
+    service:
+      name: myapp-service
+      port:
+        name: https`
+
+const ingressWildcardYaml = `apiVersion: networking.k8s.io/v1
+kind: Ingress
+metadata:
+  name: myapp-ingress
+spec:
+  rules:
+    - host: "*.example.com"
+      http:
+        paths:
+          - path: /
+            pathType: Prefix
+            backend:
+              service:
+                name: myapp-service
+                port:
+                  number: 8443`
+
 func Test_ingress_Process(t *testing.T) {
 	var testInstance ingress
 
@@ -42,4 +112,80 @@ func Test_ingress_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, false, processed)
 	})
+	t.Run("default backend without rules", func(t *testing.T) {
+		obj := internal.GenerateObj(ingressDefaultBackendYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-service"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `spec:
+  defaultBackend:
+    service:
+      name: {{ include "chart.fullname" . }}-service
+      port:
+        name: https`)
+		assert.NotContains(t, buf.String(), "rules")
+	})
+	t.Run("annotations and class name overridable", func(t *testing.T) {
+		obj := internal.GenerateObj(ingressNginxYaml)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		assert.Equal(t, helmify.Values{
+			"myappIngress": map[string]interface{}{
+				"ingress": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"nginx.ingress.kubernetes.io/rewrite-target": "/",
+						"nginx.ingress.kubernetes.io/ssl-redirect":   "false",
+					},
+					"className": "nginx",
+				},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "{{- toYaml .Values.myappIngress.ingress.annotations | nindent 4 }}")
+		assert.Contains(t, buf.String(), "ingressClassName: {{ .Values.myappIngress.ingress.className | quote }}")
+		assert.NotContains(t, buf.String(), "ssl-redirect")
+	})
+	t.Run("tls secret rewired", func(t *testing.T) {
+		obj := internal.GenerateObj(ingressTLSYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-service"))
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Secret\ntype: kubernetes.io/tls\nmetadata:\n  name: myapp-tls"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `secretName: {{ include "chart.fullname" . }}-tls`)
+		assert.Contains(t, buf.String(), "secretName: external-tls")
+	})
+	t.Run("wildcard host kept quoted", func(t *testing.T) {
+		obj := internal.GenerateObj(ingressWildcardYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-service"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		buf := bytes.Buffer{}
+		err = tmpl.Write(&buf)
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), "host: '*.example.com'")
+		assert.Contains(t, buf.String(), `name: {{ include "chart.fullname" . }}-service`)
+	})
 }
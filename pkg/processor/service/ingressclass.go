@@ -0,0 +1,80 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ingressClassGuard - wraps IngressClass to be installed only if .Values.ingressClass.create is true.
+const ingressClassGuard = `{{- if .Values.ingressClass.create }}
+%s
+{{- end }}`
+
+var ingressClassGVK = schema.GroupVersionKind{
+	Group:   "networking.k8s.io",
+	Version: "v1",
+	Kind:    "IngressClass",
+}
+
+// NewIngressClass creates processor for k8s IngressClass resource.
+func NewIngressClass() helmify.Processor {
+	return &ingressClass{}
+}
+
+type ingressClass struct{}
+
+// Process k8s IngressClass object into template. Class name is often referenced outside the chart, so it is not
+// prefixed with chart fullname but lifted to values together with spec.controller. Returns false if not capable
+// of processing given resource type.
+func (r ingressClass) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GroupVersionKind() != ingressClassGVK {
+		return false, nil, nil
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+	values := helmify.Values{
+		"ingressClass": map[string]interface{}{"create": true},
+	}
+	className, err := values.Add(obj.GetName(), nameCamel, "ingressClass", "name")
+	if err != nil {
+		return true, nil, err
+	}
+	obj = obj.DeepCopy()
+	obj.SetName(className)
+	meta, err := processor.ProcessObjMeta(appMeta, obj, processor.WithOriginalName())
+	if err != nil {
+		return true, nil, err
+	}
+
+	specMap, _, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get ingressClass spec", err)
+	}
+	if controller, ok, _ := unstructured.NestedString(specMap, "controller"); ok {
+		tpl, err := values.Add(controller, nameCamel, "ingressClass", "controller")
+		if err != nil {
+			return true, nil, err
+		}
+		specMap["controller"] = tpl
+	}
+	body := meta
+	if len(specMap) != 0 {
+		spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+		if err != nil {
+			return true, nil, err
+		}
+		body += "\n" + spec
+	}
+
+	return true, &result{
+		name:   name,
+		data:   fmt.Sprintf(ingressClassGuard, body),
+		values: values,
+	}, nil
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const ingressClassYaml = `apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: myapp-external
+  annotations:
+    ingressclass.kubernetes.io/is-default-class: "true"
+spec:
+  controller: example.com/ingress-controller
+  parameters:
+    apiGroup: k8s.example.com
+    kind: IngressParameters
+    name: external-lb`
+
+func Test_ingressClass_Process(t *testing.T) {
+	var testInstance ingressClass
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(ingressClassYaml)
+		processed, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.True(t, processed)
+		assert.Equal(t, "myapp-external.yaml", tmpl.Filename())
+		assert.Equal(t, helmify.Values{
+			"ingressClass": map[string]interface{}{"create": true},
+			"myappExternal": map[string]interface{}{
+				"ingressClass": map[string]interface{}{
+					"name":       "myapp-external",
+					"controller": "example.com/ingress-controller",
+				},
+			},
+		}, tmpl.Values())
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Equal(t, `{{- if .Values.ingressClass.create }}
+apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: {{ .Values.myappExternal.ingressClass.name | quote }}
+  labels:
+  {{- include ".labels" . | nindent 4 }}
+  annotations:
+    ingressclass.kubernetes.io/is-default-class: "true"
+spec:
+  controller: {{ .Values.myappExternal.ingressClass.controller | quote }}
+  parameters:
+    apiGroup: k8s.example.com
+    kind: IngressParameters
+    name: external-lb
+{{- end }}`, buf.String())
+	})
+	t.Run("wildcard kept quoted", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: networking.k8s.io/v1
+kind: IngressClass
+metadata:
+  name: myapp-external
+spec:
+  controller: example.com/ingress-controller
+  parameters:
+    kind: IngressParameters
+    name: "*"`)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "name: '*'")
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.False(t, processed)
+	})
+}
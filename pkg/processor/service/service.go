@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/arttor/helmify/pkg/processor"
@@ -25,7 +26,7 @@ spec:
   type: {{ .Values.%[1]s.type }}
   selector:
 %[2]s
-  {{- include "%[3]s.selectorLabels" . | nindent 4 }}
+  {{- include "%[3]s.selectorLabels" . | nindent 4 }}%[4]s
   ports:
 	{{- .Values.%[1]s.ports | toYaml | nindent 2 -}}`
 )
@@ -36,6 +37,26 @@ var svcGVC = schema.GroupVersionKind{
 	Kind:    "Service",
 }
 
+// renderedSpecFields - Service spec fields rendered to template, other fields are ignored.
+var renderedSpecFields = map[string]bool{
+	"type":                     true,
+	"selector":                 true,
+	"ports":                    true,
+	"clusterIP":                true,
+	"loadBalancerSourceRanges": true,
+	"externalTrafficPolicy":    true,
+	"loadBalancerClass":        true,
+	"sessionAffinity":          true,
+	"sessionAffinityConfig":    true,
+}
+
+// defaultSpecFields - values of Service spec fields set by API server by default. Ignoring them loses nothing.
+var defaultSpecFields = map[string]interface{}{
+	"internalTrafficPolicy":         "Cluster",
+	"ipFamilyPolicy":                "SingleStack",
+	"allocateLoadBalancerNodePorts": true,
+}
+
 // New creates processor for k8s Service resource.
 func New() helmify.Processor {
 	return &svc{}
@@ -54,18 +75,26 @@ func (r svc) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 		return true, nil, fmt.Errorf("%w: unable to cast to service", err)
 	}
 
+	if ignored := ignoredSpecFields(obj); len(ignored) != 0 {
+		if err = processor.ReportLossy(appMeta, obj, "spec fields ignored: "+strings.Join(ignored, ", ")); err != nil {
+			return true, nil, err
+		}
+	}
+
 	meta, err := processor.ProcessObjMeta(appMeta, obj)
 	if err != nil {
 		return true, nil, err
 	}
 
-	name := appMeta.TrimName(obj.GetName())
-	shortName := strings.TrimPrefix(name, "controller-manager-")
+	shortName := serviceName(appMeta, obj.GetName())
 	shortNameCamel := strcase.ToLowerCamel(shortName)
 
-	selector, _ := yaml.Marshal(service.Spec.Selector)
-	selector = yamlformat.Indent(selector, 4)
-	selector = bytes.TrimRight(selector, "\n ")
+	var selector []byte
+	if len(service.Spec.Selector) != 0 {
+		selector, _ = yaml.Marshal(service.Spec.Selector)
+		selector = yamlformat.Indent(selector, 4)
+		selector = bytes.TrimRight(selector, "\n ")
+	}
 
 	values := helmify.Values{}
 	svcType := service.Spec.Type
@@ -87,15 +116,31 @@ func (r svc) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 		if p.Protocol != "" {
 			pMap["protocol"] = string(p.Protocol)
 		}
-		if p.TargetPort.Type == intstr.Int {
+		if p.AppProtocol != nil {
+			pMap["appProtocol"] = *p.AppProtocol
+		}
+		// omit unset targetPort to keep k8s default equal to port
+		if p.TargetPort.Type == intstr.Int && p.TargetPort.IntVal != 0 {
 			pMap["targetPort"] = int64(p.TargetPort.IntVal)
-		} else {
+		} else if p.TargetPort.Type == intstr.String {
 			pMap["targetPort"] = p.TargetPort.StrVal
 		}
 		ports[i] = pMap
 	}
 	_ = unstructured.SetNestedSlice(values, ports, shortNameCamel, "ports")
-	res := meta + fmt.Sprintf(svcTempSpec, shortNameCamel, selector, appMeta.ChartName())
+	clusterIP, err := processClusterIP(shortNameCamel, service, values)
+	if err != nil {
+		return true, nil, err
+	}
+	loadBalancer, err := processLoadBalancer(shortNameCamel, service.Spec, values)
+	if err != nil {
+		return true, nil, err
+	}
+	sessionAffinity, err := processSessionAffinity(shortNameCamel, service.Spec, values)
+	if err != nil {
+		return true, nil, err
+	}
+	res := meta + fmt.Sprintf(svcTempSpec, shortNameCamel, selector, appMeta.ChartName(), clusterIP+loadBalancer+sessionAffinity)
 	return true, &result{
 		name:   shortName,
 		data:   res,
@@ -103,6 +148,109 @@ func (r svc) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 	}, nil
 }
 
+// ValuesName - returns name of values block with Service type and ports, e.g. webhookService.
+func ValuesName(appMeta helmify.AppMetadata, objName string) string {
+	return strcase.ToLowerCamel(serviceName(appMeta, objName))
+}
+
+func serviceName(appMeta helmify.AppMetadata, objName string) string {
+	return strings.TrimPrefix(appMeta.TrimName(objName), "controller-manager-")
+}
+
+// ignoredSpecFields - returns sorted spec fields of given Service which are not rendered to template. Fields with
+// default values and single cluster IP and IP family, e.g. assigned by API server, are not returned.
+func ignoredSpecFields(obj *unstructured.Unstructured) []string {
+	spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+	var res []string
+	for field, val := range spec {
+		if renderedSpecFields[field] {
+			continue
+		}
+		if def, ok := defaultSpecFields[field]; ok && def == val {
+			continue
+		}
+		if list, ok := val.([]interface{}); ok && len(list) <= 1 && (field == "clusterIPs" || field == "ipFamilies") {
+			continue
+		}
+		res = append(res, field)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// processClusterIP - lifts static clusterIP set in the source to values. clusterIP None of headless Service is kept
+// literal: it is immutable and overriding it turns the Service into a normal one. Services exported from a cluster
+// have clusterIP assigned by API server, it is dropped. Such services are told by metadata set by API server.
+func processClusterIP(name string, service corev1.Service, values helmify.Values) (string, error) {
+	if service.Spec.ClusterIP == "" || service.Spec.Type == corev1.ServiceTypeExternalName {
+		return "", nil
+	}
+	if service.Spec.ClusterIP == corev1.ClusterIPNone {
+		return "\n  clusterIP: None", nil
+	}
+	if service.UID != "" || service.ResourceVersion != "" || !service.CreationTimestamp.IsZero() {
+		return "", nil
+	}
+	tpl, err := values.Add(service.Spec.ClusterIP, name, "clusterIP")
+	if err != nil {
+		return "", err
+	}
+	return "\n  clusterIP: " + tpl, nil
+}
+
+// processLoadBalancer - lifts environment specific load balancer fields set in the source to values.
+func processLoadBalancer(name string, spec corev1.ServiceSpec, values helmify.Values) (string, error) {
+	var res strings.Builder
+	if len(spec.LoadBalancerSourceRanges) != 0 {
+		ranges := make([]interface{}, len(spec.LoadBalancerSourceRanges))
+		for i, r := range spec.LoadBalancerSourceRanges {
+			ranges[i] = r
+		}
+		tpl, err := values.AddYaml(ranges, 2, true, name, "loadBalancerSourceRanges")
+		if err != nil {
+			return "", err
+		}
+		res.WriteString("\n  loadBalancerSourceRanges: " + tpl)
+	}
+	if spec.ExternalTrafficPolicy != "" {
+		tpl, err := values.Add(string(spec.ExternalTrafficPolicy), name, "externalTrafficPolicy")
+		if err != nil {
+			return "", err
+		}
+		res.WriteString("\n  externalTrafficPolicy: " + tpl)
+	}
+	if spec.LoadBalancerClass != nil {
+		tpl, err := values.Add(*spec.LoadBalancerClass, name, "loadBalancerClass")
+		if err != nil {
+			return "", err
+		}
+		res.WriteString("\n  loadBalancerClass: " + tpl)
+	}
+	return res.String(), nil
+}
+
+// processSessionAffinity - lifts sessionAffinity other than None and its client IP timeout to values.
+func processSessionAffinity(name string, spec corev1.ServiceSpec, values helmify.Values) (string, error) {
+	if spec.SessionAffinity == "" || spec.SessionAffinity == corev1.ServiceAffinityNone {
+		return "", nil
+	}
+	var res strings.Builder
+	tpl, err := values.Add(string(spec.SessionAffinity), name, "sessionAffinity")
+	if err != nil {
+		return "", err
+	}
+	res.WriteString("\n  sessionAffinity: " + tpl)
+	cfg := spec.SessionAffinityConfig
+	if cfg != nil && cfg.ClientIP != nil && cfg.ClientIP.TimeoutSeconds != nil {
+		tpl, err = values.Add(int64(*cfg.ClientIP.TimeoutSeconds), name, "sessionAffinityConfig", "clientIP", "timeoutSeconds")
+		if err != nil {
+			return "", err
+		}
+		res.WriteString("\n  sessionAffinityConfig:\n    clientIP:\n      timeoutSeconds: " + tpl)
+	}
+	return res.String(), nil
+}
+
 type result struct {
 	name   string
 	data   string
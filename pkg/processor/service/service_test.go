@@ -1,9 +1,15 @@
 package service
 
 import (
+	"bytes"
+	"errors"
+	"strings"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
 	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
 
 	"github.com/arttor/helmify/internal"
 	"github.com/stretchr/testify/assert"
@@ -24,6 +30,56 @@ spec:
   selector:
     control-plane: controller-manager`
 
+const svcPortsYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: dns
+spec:
+  type: NodePort
+  ports:
+  - name: dns-udp
+    port: 53
+    protocol: UDP
+    targetPort: dns
+  - name: dns-sctp
+    port: 5353
+    protocol: SCTP
+    nodePort: 30053
+  - name: web
+    port: 80
+    appProtocol: http
+    targetPort: 8080
+  selector:
+    app: dns`
+
+const svcLoadBalancerYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: LoadBalancer
+  loadBalancerClass: internal-lb
+  loadBalancerSourceRanges:
+  - 10.0.0.0/8
+  - 192.168.0.0/16
+  externalTrafficPolicy: Local
+  ports:
+  - port: 80
+  selector:
+    app: web`
+
+const svcSessionAffinityYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  ports:
+  - port: 80
+  sessionAffinity: ClientIP
+  sessionAffinityConfig:
+    clientIP:
+      timeoutSeconds: 3600`
+
 func Test_svc_Process(t *testing.T) {
 	var testInstance svc
 
@@ -33,6 +89,145 @@ func Test_svc_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("ports preserved", func(t *testing.T) {
+		obj := internal.GenerateObj(svcPortsYaml)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"dns": map[string]interface{}{
+				"type": "NodePort",
+				"ports": []interface{}{
+					map[string]interface{}{"name": "dns-udp", "port": int64(53), "protocol": "UDP", "targetPort": "dns"},
+					map[string]interface{}{"name": "dns-sctp", "port": int64(5353), "protocol": "SCTP", "nodePort": int64(30053)},
+					map[string]interface{}{"name": "web", "port": int64(80), "appProtocol": "http", "targetPort": int64(8080)},
+				},
+			},
+		}, tmpl.Values())
+	})
+	t.Run("load balancer", func(t *testing.T) {
+		obj := internal.GenerateObj(svcLoadBalancerYaml)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"web": map[string]interface{}{
+				"type":                     "LoadBalancer",
+				"ports":                    []interface{}{map[string]interface{}{"port": int64(80)}},
+				"loadBalancerSourceRanges": []interface{}{"10.0.0.0/8", "192.168.0.0/16"},
+				"externalTrafficPolicy":    "Local",
+				"loadBalancerClass":        "internal-lb",
+			},
+		}, tmpl.Values())
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `
+  loadBalancerSourceRanges: {{ .Values.web.loadBalancerSourceRanges | toYaml | nindent 2 }}
+  externalTrafficPolicy: {{ .Values.web.externalTrafficPolicy | quote }}
+  loadBalancerClass: {{ .Values.web.loadBalancerClass | quote }}
+  ports:`)
+	})
+	t.Run("load balancer fields omitted", func(t *testing.T) {
+		obj := internal.GenerateObj(svcPortsYaml)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.NotContains(t, buf.String(), "loadBalancer")
+		assert.NotContains(t, buf.String(), "externalTrafficPolicy")
+		assert.NotContains(t, buf.String(), "sessionAffinity")
+	})
+	t.Run("session affinity", func(t *testing.T) {
+		obj := internal.GenerateObj(svcSessionAffinityYaml)
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, helmify.Values{
+			"web": map[string]interface{}{
+				"type":            "ClusterIP",
+				"ports":           []interface{}{map[string]interface{}{"port": int64(80)}},
+				"sessionAffinity": "ClientIP",
+				"sessionAffinityConfig": map[string]interface{}{
+					"clientIP": map[string]interface{}{"timeoutSeconds": int64(3600)},
+				},
+			},
+		}, tmpl.Values())
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `
+  sessionAffinity: {{ .Values.web.sessionAffinity | quote }}
+  sessionAffinityConfig:
+    clientIP:
+      timeoutSeconds: {{ .Values.web.sessionAffinityConfig.clientIP.timeoutSeconds }}
+  ports:`)
+	})
+	t.Run("cluster IP", func(t *testing.T) {
+		const svc = `apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  clusterIP: 10.96.0.10
+  ports:
+  - port: 80`
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, internal.GenerateObj(svc))
+		assert.NoError(t, err)
+		assert.Equal(t, "10.96.0.10", tmpl.Values()["web"].(map[string]interface{})["clusterIP"], "static")
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "\n  clusterIP: {{ .Values.web.clusterIP | quote }}\n")
+
+		exported := strings.Replace(svc, "  name: web\n", "  name: web\n  uid: 3c6d4a1e-8f0b-4d7e-9a52-0b1f7e2c9d11\n  resourceVersion: \"1234\"\n", 1)
+		_, tmpl, err = testInstance.Process(&metadata.Service{}, internal.GenerateObj(exported))
+		assert.NoError(t, err)
+		assert.NotContains(t, tmpl.Values()["web"], "clusterIP", "dynamic")
+		buf.Reset()
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.NotContains(t, buf.String(), "clusterIP")
+
+		headless := strings.Replace(exported, "10.96.0.10", "None", 1)
+		_, tmpl, err = testInstance.Process(&metadata.Service{}, internal.GenerateObj(headless))
+		assert.NoError(t, err)
+		assert.NotContains(t, tmpl.Values()["web"], "clusterIP", "headless")
+		buf.Reset()
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "\n  clusterIP: None\n", "headless kept literal")
+	})
+	t.Run("ignored spec fields reported", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+spec:
+  type: ExternalName
+  externalName: web.example.com
+  externalIPs:
+  - 80.11.12.10
+  internalTrafficPolicy: Cluster
+  ports:
+  - port: 80`)
+		_, _, err := testInstance.Process(metadata.New(config.Config{}), obj)
+		assert.NoError(t, err, "reported as warning")
+		_, _, err = testInstance.Process(metadata.New(config.Config{Strict: true}), obj)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+		assert.EqualError(t, err, "lossy conversion: Service web: spec fields ignored: externalIPs, externalName")
+	})
+	t.Run("server defaults not reported", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  uid: 3c6d4a1e-8f0b-4d7e-9a52-0b1f7e2c9d11
+spec:
+  clusterIP: 10.96.0.10
+  clusterIPs:
+  - 10.96.0.10
+  ipFamilies:
+  - IPv4
+  ipFamilyPolicy: SingleStack
+  internalTrafficPolicy: Cluster
+  ports:
+  - port: 80`)
+		_, _, err := testInstance.Process(metadata.New(config.Config{Strict: true}), obj)
+		assert.NoError(t, err)
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
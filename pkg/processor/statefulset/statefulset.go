@@ -108,10 +108,17 @@ func (d statefulset) Process(appMeta helmify.AppMetadata, obj *unstructured.Unst
 	}
 
 	// process pod spec:
+	if err := pod.ReportDroppedFields(appMeta, obj, ssSpec.Template.Spec, "spec", "template", "spec"); err != nil {
+		return true, nil, err
+	}
 	podSpecMap, podValues, err := pod.ProcessSpec(nameCamel, appMeta, ssSpec.Template.Spec)
 	if err != nil {
 		return true, nil, err
 	}
+	err = pod.ProcessInitContainerRestartPolicy(nameCamel, appMeta, podSpecMap, &podValues, obj.Object, "spec", "template", "spec")
+	if err != nil {
+		return true, nil, err
+	}
 	err = values.Merge(podValues)
 	if err != nil {
 		return true, nil, err
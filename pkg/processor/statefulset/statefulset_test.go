@@ -0,0 +1,79 @@
+package statefulset
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	strStatefulSet = `apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: my-app-db
+spec:
+  serviceName: my-app-db-headless
+  replicas: 2
+  selector:
+    matchLabels:
+      app: db
+  template:
+    metadata:
+      labels:
+        app: db
+    spec:
+      containers:
+      - name: db
+        image: postgres:15.4`
+	strHeadlessSvc = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-app-db-headless
+spec:
+  clusterIP: None
+  selector:
+    app: db
+  ports:
+  - port: 5432`
+)
+
+func Test_statefulset_Process(t *testing.T) {
+	var testInstance statefulset
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strStatefulSet)
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+	t.Run("serviceName matches headless service", func(t *testing.T) {
+		ssObj := internal.GenerateObj(strStatefulSet)
+		svcObj := internal.GenerateObj(strHeadlessSvc)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(ssObj)
+		appMeta.Load(svcObj)
+
+		_, svcTmpl, err := service.New().Process(appMeta, svcObj)
+		assert.NoError(t, err)
+		svcBuf := bytes.Buffer{}
+		assert.NoError(t, svcTmpl.Write(&svcBuf))
+		assert.Contains(t, svcBuf.String(), `name: {{ include "chart.fullname" . }}-headless`)
+
+		_, ssTmpl, err := testInstance.Process(appMeta, ssObj)
+		assert.NoError(t, err)
+		ssBuf := bytes.Buffer{}
+		assert.NoError(t, ssTmpl.Write(&ssBuf))
+		assert.Contains(t, ssBuf.String(), `serviceName: {{ include "chart.fullname" . }}-headless`)
+	})
+}
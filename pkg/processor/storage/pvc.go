@@ -19,6 +19,9 @@ var pvcTempl, _ = template.New("pvc").Parse(
 	`{{ .Meta }}
 {{ .Spec }}`)
 
+// globalStorageClass - storage class name from .Values.global.storageClass with fallback to the PVC value.
+const globalStorageClass = "{{ .Values.global.storageClass | default .Values.pvc.%s.storageClass | quote }}"
+
 var pvcGVC = schema.GroupVersionKind{
 	Group:   "",
 	Version: "v1",
@@ -58,6 +61,13 @@ func (p pvc) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 		if err != nil {
 			return true, nil, err
 		}
+		if appMeta.Config().GlobalValues {
+			templatedSC = fmt.Sprintf(globalStorageClass, nameCamelCase)
+			err = unstructured.SetNestedField(values, "", "global", "storageClass")
+			if err != nil {
+				return true, nil, fmt.Errorf("%w: unable to set global storage class value", err)
+			}
+		}
 		claim.Spec.StorageClassName = &templatedSC
 	}
 
@@ -1,8 +1,11 @@
 package storage
 
 import (
+	"bytes"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
+
 	"github.com/arttor/helmify/pkg/metadata"
 
 	"github.com/arttor/helmify/internal"
@@ -32,6 +35,20 @@ func Test_PVC_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("global storage class", func(t *testing.T) {
+		obj := internal.GenerateObj(pvcYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart", GlobalValues: true})
+		appMeta.Load(obj)
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+
+		var buf bytes.Buffer
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "storageClassName: {{ .Values.global.storageClass | default .Values.pvc.taskPvClaim.storageClass")
+		assert.Equal(t, "", tmpl.Values()["global"].(map[string]interface{})["storageClass"])
+		assert.Equal(t, "manual", tmpl.Values()["pvc"].(map[string]interface{})["taskPvClaim"].(map[string]interface{})["storageClass"])
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
@@ -0,0 +1,153 @@
+package traefik
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	yamlformat "github.com/arttor/helmify/pkg/yaml"
+	"github.com/iancoleman/strcase"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const ingressRouteKind = "IngressRoute"
+
+// ingressRouteGroups - API groups of Traefik CRDs: current and deprecated one used before Traefik v3.
+var ingressRouteGroups = []string{"traefik.io", "traefik.containo.us"}
+
+const matchTemplate = "{{ index .Values.%s.matches %d | quote }}"
+
+// NewIngressRoute creates processor for Traefik IngressRoute resource.
+func NewIngressRoute() helmify.Processor {
+	return &ingressRoute{}
+}
+
+type ingressRoute struct{}
+
+// Process Traefik IngressRoute object into template. Route match rules are lifted to values, names of referenced
+// Services, Middlewares and TLS secret are templated if they are in the chart. Returns false if not capable of
+// processing given resource type.
+func (r ingressRoute) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured) (bool, helmify.Template, error) {
+	if obj.GetKind() != ingressRouteKind || !contains(ingressRouteGroups, obj.GroupVersionKind().Group) {
+		return false, nil, nil
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	name := appMeta.TrimName(obj.GetName())
+	nameCamel := strcase.ToLowerCamel(name)
+
+	specMap, exists, err := unstructured.NestedMap(obj.Object, "spec")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get ingressRoute spec", err)
+	}
+	if !exists {
+		return true, nil, fmt.Errorf("no ingressRoute spec presented")
+	}
+
+	routes, _, err := unstructured.NestedSlice(specMap, "routes")
+	if err != nil {
+		return true, nil, fmt.Errorf("%w: unable to get ingressRoute routes", err)
+	}
+	var matches []interface{}
+	for _, route := range routes {
+		routeMap, ok := route.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if match, ok, _ := unstructured.NestedString(routeMap, "match"); ok {
+			routeMap["match"] = fmt.Sprintf(matchTemplate, nameCamel, len(matches))
+			matches = append(matches, match)
+		}
+		for _, field := range []string{"services", "middlewares"} {
+			if err = templateRefs(appMeta, routeMap, field); err != nil {
+				return true, nil, err
+			}
+		}
+	}
+	if len(routes) != 0 {
+		specMap["routes"] = routes
+	}
+	if secret, ok, _ := unstructured.NestedString(specMap, "tls", "secretName"); ok {
+		err = unstructured.SetNestedField(specMap, appMeta.TemplatedName(secret), "tls", "secretName")
+		if err != nil {
+			return true, nil, fmt.Errorf("%w: unable to template ingressRoute tls secret", err)
+		}
+	}
+
+	values := helmify.Values{}
+	if len(matches) != 0 {
+		if err = unstructured.SetNestedSlice(values, matches, nameCamel, "matches"); err != nil {
+			return true, nil, fmt.Errorf("%w: unable to set ingressRoute matches to values", err)
+		}
+	}
+
+	spec, err := yamlformat.MarshalTemplated(map[string]interface{}{"spec": specMap}, 0)
+	if err != nil {
+		return true, nil, err
+	}
+
+	return true, &result{
+		name:   name + ".yaml",
+		data:   []byte(meta + "\n" + spec),
+		values: values,
+	}, nil
+}
+
+// templateRefs - replaces names of chart objects referenced in given route field with templated names. References
+// to objects in other namespaces are left as is.
+func templateRefs(appMeta helmify.AppMetadata, route map[string]interface{}, field string) error {
+	refs, exists, err := unstructured.NestedSlice(route, field)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get ingressRoute %s", err, field)
+	}
+	if !exists {
+		return nil
+	}
+	for _, ref := range refs {
+		refMap, ok := ref.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ns, _, _ := unstructured.NestedString(refMap, "namespace"); ns != "" {
+			if ns != appMeta.Namespace() {
+				continue
+			}
+			refMap["namespace"] = "{{ .Release.Namespace }}"
+		}
+		if name, _, _ := unstructured.NestedString(refMap, "name"); name != "" {
+			refMap["name"] = appMeta.TemplatedName(name)
+		}
+	}
+	return unstructured.SetNestedSlice(route, refs, field)
+}
+
+func contains(list []string, val string) bool {
+	for _, v := range list {
+		if v == val {
+			return true
+		}
+	}
+	return false
+}
+
+type result struct {
+	name   string
+	data   []byte
+	values helmify.Values
+}
+
+func (r *result) Filename() string {
+	return r.name
+}
+
+func (r *result) Values() helmify.Values {
+	return r.values
+}
+
+func (r *result) Write(writer io.Writer) error {
+	_, err := writer.Write(r.data)
+	return err
+}
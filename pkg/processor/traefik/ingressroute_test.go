@@ -0,0 +1,105 @@
+package traefik
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/arttor/helmify/internal"
+	"github.com/arttor/helmify/pkg/config"
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/stretchr/testify/assert"
+)
+
+const strIngressRoute = `apiVersion: traefik.io/v1alpha1
+kind: IngressRoute
+metadata:
+  name: myapp-web
+  namespace: myapp
+spec:
+  entryPoints:
+  - websecure
+  routes:
+  - match: Host(` + "`example.com`" + `) && PathPrefix(` + "`/`" + `)
+    kind: Rule
+    services:
+    - name: myapp-web
+      port: 80
+    middlewares:
+    - name: myapp-strip
+      namespace: myapp
+    - name: shared-auth
+      namespace: traefik
+  tls:
+    secretName: myapp-tls`
+
+const strMiddleware = `apiVersion: traefik.io/v1alpha1
+kind: Middleware
+metadata:
+  name: myapp-strip
+  namespace: myapp
+spec:
+  stripPrefix:
+    prefixes:
+    - /api`
+
+func Test_ingressRoute_Process(t *testing.T) {
+	var testInstance ingressRoute
+
+	t.Run("processed", func(t *testing.T) {
+		obj := internal.GenerateObj(strIngressRoute)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(strMiddleware))
+		appMeta.Load(internal.GenerateObj("apiVersion: v1\nkind: Service\nmetadata:\n  name: myapp-web\n  namespace: myapp"))
+		processed, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+		assert.Equal(t, "web.yaml", tmpl.Filename())
+		assert.Equal(t, helmify.Values{
+			"web": map[string]interface{}{
+				"matches": []interface{}{"Host(`example.com`) && PathPrefix(`/`)"},
+			},
+		}, tmpl.Values())
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `spec:
+  entryPoints:
+  - websecure
+  routes:
+  - kind: Rule
+    match: {{ index .Values.web.matches 0 | quote }}
+    middlewares:
+    - name: {{ include "chart.fullname" . }}-strip
+      namespace: {{ .Release.Namespace }}
+    - name: shared-auth
+      namespace: traefik
+    services:
+    - name: {{ include "chart.fullname" . }}-web
+      port: 80
+  tls:
+    secretName: myapp-tls`)
+	})
+	t.Run("deprecated group", func(t *testing.T) {
+		obj := internal.GenerateObj("apiVersion: traefik.containo.us/v1alpha1\nkind: IngressRoute\nmetadata:\n  name: web\nspec:\n  routes: []")
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, true, processed)
+	})
+	t.Run("wildcard tls domain kept quoted", func(t *testing.T) {
+		obj := internal.GenerateObj("apiVersion: traefik.io/v1alpha1\nkind: IngressRoute\nmetadata:\n  name: web\nspec:\n  routes: []\n  tls:\n    domains:\n    - main: example.com\n      sans:\n      - \"*.example.com\"")
+		_, tmpl, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "- '*.example.com'")
+	})
+	t.Run("skipped", func(t *testing.T) {
+		obj := internal.TestNs
+		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
+		assert.NoError(t, err)
+		assert.Equal(t, false, processed)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/arttor/helmify/pkg/cluster"
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
 	yamlformat "github.com/arttor/helmify/pkg/yaml"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -15,24 +16,20 @@ import (
 )
 
 const (
-	certTempl = `apiVersion: cert-manager.io/v1
+	certTempl = `apiVersion: %[5]s
 kind: Certificate
 metadata:
   name: {{ include "%[1]s.fullname" . }}-%[2]s
-  labels:
-  {{- include "%[1]s.labels" . | nindent 4 }}
-spec:
+%[4]sspec:
 %[3]s`
-	certTemplWithAnno = `apiVersion: cert-manager.io/v1
+	certTemplWithAnno = `apiVersion: %[5]s
 kind: Certificate
 metadata:
   name: {{ include "%[1]s.fullname" . }}-%[2]s
   annotations:
     "helm.sh/hook": post-install,post-upgrade
     "helm.sh/hook-weight": "2"
-  labels:
-  {{- include "%[1]s.labels" . | nindent 4 }}
-spec:
+%[4]sspec:
 %[3]s`
 )
 
@@ -92,7 +89,11 @@ func (c cert) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructure
 	} else {
 		tmpl = certTempl
 	}
-	res := fmt.Sprintf(tmpl, appMeta.ChartName(), name, string(spec))
+	labels := processor.LabelsBlock(appMeta, "")
+	if labels != "" {
+		labels += "\n"
+	}
+	res := fmt.Sprintf(tmpl, appMeta.ChartName(), name, string(spec), labels, processor.APIVersion(appMeta, obj))
 	return true, &certResult{
 		name: name,
 		data: []byte(res),
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
 	yamlformat "github.com/arttor/helmify/pkg/yaml"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -13,24 +14,20 @@ import (
 )
 
 const (
-	issuerTempl = `apiVersion: cert-manager.io/v1
+	issuerTempl = `apiVersion: %[5]s
 kind: Issuer
 metadata:
   name: {{ include "%[1]s.fullname" . }}-%[2]s
-  labels:
-  {{- include "%[1]s.labels" . | nindent 4 }}
-spec:
+%[4]sspec:
 %[3]s`
-	issuerTemplWithAnno = `apiVersion: cert-manager.io/v1
+	issuerTemplWithAnno = `apiVersion: %[5]s
 kind: Issuer
 metadata:
   name: {{ include "%[1]s.fullname" . }}-%[2]s
   annotations:
     "helm.sh/hook": post-install,post-upgrade
     "helm.sh/hook-weight": "1"
-  labels:
-  {{- include "%[1]s.labels" . | nindent 4 }}
-spec:
+%[4]sspec:
 %[3]s`
 )
 
@@ -62,7 +59,11 @@ func (i issuer) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructu
 	} else {
 		tmpl = issuerTempl
 	}
-	res := fmt.Sprintf(tmpl, appMeta.ChartName(), name, string(spec))
+	labels := processor.LabelsBlock(appMeta, "")
+	if labels != "" {
+		labels += "\n"
+	}
+	res := fmt.Sprintf(tmpl, appMeta.ChartName(), name, string(spec), labels, processor.APIVersion(appMeta, obj))
 	return true, &issResult{
 		name: name,
 		data: []byte(res),
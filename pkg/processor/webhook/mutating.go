@@ -1,30 +1,21 @@
 package webhook
 
 import (
-	"bytes"
 	"fmt"
 	"io"
-	"strings"
 
 	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
 	v1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"sigs.k8s.io/yaml"
 )
 
 const (
-	mwhTempl = `apiVersion: admissionregistration.k8s.io/v1
-kind: MutatingWebhookConfiguration
-metadata:
-  name: {{ include "%[1]s.fullname" . }}-%[2]s
-  annotations:
-    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "%[1]s.fullname" . }}-%[3]s
-  labels:
-  {{- include "%[1]s.labels" . | nindent 4 }}
+	mwhTempl = `%[1]s
 webhooks:
-%[4]s`
+%[2]s`
 )
 
 var mwhGVK = schema.GroupVersionKind{
@@ -52,20 +43,29 @@ func (w mwh) Process(appMeta helmify.AppMetadata, obj *unstructured.Unstructured
 	if err != nil {
 		return true, nil, fmt.Errorf("%w: unable to cast to MutatingWebhookConfiguration", err)
 	}
-	for i, whc := range whConf.Webhooks {
-		whc.ClientConfig.Service.Name = appMeta.TemplatedName(whc.ClientConfig.Service.Name)
-		whc.ClientConfig.Service.Namespace = strings.ReplaceAll(whc.ClientConfig.Service.Namespace, appMeta.Namespace(), `{{ .Release.Namespace }}`)
-		whConf.Webhooks[i] = whc
+	ports := map[int]string{}
+	for i := range whConf.Webhooks {
+		if port := rewireService(appMeta, whConf.Webhooks[i].ClientConfig.Service); port != "" {
+			ports[i] = port
+		}
 	}
-	webhooks, _ := yaml.Marshal(whConf.Webhooks)
-	webhooks = bytes.TrimRight(webhooks, "\n ")
-	certName, _, err := unstructured.NestedString(obj.Object, "metadata", "annotations", "cert-manager.io/inject-ca-from")
+	whMap, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&whConf)
 	if err != nil {
-		return true, nil, fmt.Errorf("%w: unable get webhook certName", err)
+		return true, nil, fmt.Errorf("%w: unable to convert MutatingWebhookConfiguration", err)
 	}
-	certName = strings.TrimPrefix(certName, appMeta.Namespace()+"/")
-	certName = appMeta.TrimName(certName)
-	res := fmt.Sprintf(mwhTempl, appMeta.ChartName(), name, certName, string(webhooks))
+	if err = reportDroppedFields(appMeta, obj, whMap); err != nil {
+		return true, nil, err
+	}
+	whList, _, _ := unstructured.NestedSlice(whMap, "webhooks")
+	webhooks, err := marshalWebhooks(whList, ports)
+	if err != nil {
+		return true, nil, err
+	}
+	meta, err := processor.ProcessObjMeta(appMeta, obj)
+	if err != nil {
+		return true, nil, err
+	}
+	res := fmt.Sprintf(mwhTempl, meta, webhooks)
 	return true, &mwhResult{
 		name: name,
 		data: []byte(res),
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/arttor/helmify/pkg/helmify"
+	"github.com/arttor/helmify/pkg/processor"
+	"github.com/arttor/helmify/pkg/processor/service"
+	v1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// servicePortTemplate - webhook service port following the port of chart Service lifted to values.
+const servicePortTemplate = "{{ (index .Values.%s.ports %d).port }}"
+
+// defaultServicePort - webhook service port used by k8s if not set.
+const defaultServicePort = 443
+
+// rewireService - templates name and namespace of Service referenced by webhook client config. Returns template
+// of the port referencing values of the Service port if the Service is in the chart, otherwise empty string.
+func rewireService(appMeta helmify.AppMetadata, ref *v1.ServiceReference) string {
+	if ref == nil {
+		return ""
+	}
+	ports, found := appMeta.ServicePorts(ref.Name)
+	valuesName := service.ValuesName(appMeta, ref.Name)
+	ref.Name = appMeta.TemplatedName(ref.Name)
+	ref.Namespace = strings.ReplaceAll(ref.Namespace, appMeta.Namespace(), `{{ .Release.Namespace }}`)
+	if !found {
+		return ""
+	}
+	port := int32(defaultServicePort)
+	if ref.Port != nil {
+		port = *ref.Port
+	}
+	for i, p := range ports {
+		if p.Port == port {
+			return fmt.Sprintf(servicePortTemplate, valuesName, i)
+		}
+	}
+	return ""
+}
+
+// reportDroppedFields - reports fields of webhook configuration dropped by conversion to typed object, e.g. fields
+// unknown to the used k8s API version. See processor.ReportLossy.
+func reportDroppedFields(appMeta helmify.AppMetadata, obj *unstructured.Unstructured, typed map[string]interface{}) error {
+	dropped := processor.DroppedFields(obj.Object, typed)
+	if len(dropped) == 0 {
+		return nil
+	}
+	return processor.ReportLossy(appMeta, obj, "webhook fields dropped: "+strings.Join(dropped, ", "))
+}
+
+// marshalWebhooks - marshals given webhooks with client config service ports set to given templates by webhook
+// index.
+func marshalWebhooks(webhooks []interface{}, ports map[int]string) (string, error) {
+	for i, port := range ports {
+		wh, ok := webhooks[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := unstructured.SetNestedField(wh, port, "clientConfig", "service", "port"); err != nil {
+			return "", fmt.Errorf("%w: unable to set webhook service port", err)
+		}
+	}
+	res, err := yaml.Marshal(webhooks)
+	if err != nil {
+		return "", fmt.Errorf("%w: unable to marshal webhooks", err)
+	}
+	out := strings.TrimRight(string(res), "\n ")
+	for _, port := range ports {
+		out = strings.ReplaceAll(out, "'"+port+"'", port)
+	}
+	return out, nil
+}
@@ -1,9 +1,13 @@
 package webhook
 
 import (
+	"bytes"
+	"errors"
 	"testing"
 
+	"github.com/arttor/helmify/pkg/config"
 	"github.com/arttor/helmify/pkg/metadata"
+	"github.com/arttor/helmify/pkg/processor"
 
 	"github.com/arttor/helmify/internal"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +42,21 @@ webhooks:
     - volumes
   sideEffects: None`
 
+const webhookSvcYaml = `apiVersion: v1
+kind: Service
+metadata:
+  name: my-operator-webhook-service
+  namespace: my-operator-system
+spec:
+  ports:
+  - name: metrics
+    port: 8443
+  - name: webhook
+    port: 443
+    targetPort: 9443
+  selector:
+    control-plane: controller-manager`
+
 func Test_vwh_Process(t *testing.T) {
 	var testInstance vwh
 
@@ -47,6 +66,74 @@ func Test_vwh_Process(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, true, processed)
 	})
+	t.Run("inject-ca-from annotation", func(t *testing.T) {
+		obj := internal.GenerateObj(vwhYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(certYaml))
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `  annotations:
+    cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "chart.fullname" . }}-serving-cert
+webhooks:`)
+	})
+	t.Run("service port follows service values", func(t *testing.T) {
+		obj := internal.GenerateObj(vwhYaml)
+		appMeta := metadata.New(config.Config{ChartName: "chart"})
+		appMeta.Load(obj)
+		appMeta.Load(internal.GenerateObj(webhookSvcYaml))
+		_, tmpl, err := testInstance.Process(appMeta, obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), `      path: /validate-ceph-example-com-v1alpha1-volume
+      port: {{ (index .Values.webhookService.ports 1).port }}
+`)
+	})
+	t.Run("url client config", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: my-operator-validating-webhook-configuration
+webhooks:
+- admissionReviewVersions:
+  - v1
+  clientConfig:
+    url: https://webhook.example.com/validate
+  name: vvolume.kb.io
+  sideEffects: None`)
+		_, tmpl, err := testInstance.Process(metadata.New(config.Config{ChartName: "chart"}), obj)
+		assert.NoError(t, err)
+
+		buf := bytes.Buffer{}
+		assert.NoError(t, tmpl.Write(&buf))
+		assert.Contains(t, buf.String(), "url: https://webhook.example.com/validate")
+	})
+	t.Run("dropped fields reported", func(t *testing.T) {
+		obj := internal.GenerateObj(`apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: my-operator-validating-webhook-configuration
+webhooks:
+- admissionReviewVersions:
+  - v1
+  clientConfig:
+    url: https://webhook.example.com/validate
+  matchConditions:
+  - name: exclude-leases
+    expression: '!(request.resource.group == "coordination.k8s.io")'
+  name: vvolume.kb.io
+  sideEffects: None`)
+		_, _, err := testInstance.Process(metadata.New(config.Config{ChartName: "chart"}), obj)
+		assert.NoError(t, err, "reported as warning")
+		_, _, err = testInstance.Process(metadata.New(config.Config{ChartName: "chart", Strict: true}), obj)
+		assert.True(t, errors.Is(err, processor.ErrLossyConversion))
+		assert.ErrorContains(t, err, "webhook fields dropped: webhooks[0].matchConditions")
+	})
 	t.Run("skipped", func(t *testing.T) {
 		obj := internal.TestNs
 		processed, _, err := testInstance.Process(&metadata.Service{}, obj)
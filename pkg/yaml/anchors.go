@@ -0,0 +1,141 @@
+package yaml
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+)
+
+// MarshalAnchored - marshals object to yaml replacing repeated mappings and sequences with aliases to the first
+// occurrence marked with an anchor named after its key, e.g. 'resources: &resources' and 'resources: *resources'.
+// Empty mappings and sequences are not anchored.
+func MarshalAnchored(object interface{}) ([]byte, error) {
+	plain, err := yaml.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	var doc yamlv3.Node
+	if err = yamlv3.Unmarshal(plain, &doc); err != nil {
+		return nil, fmt.Errorf("%w: unable to parse marshaled yaml", err)
+	}
+	a := anchors{counts: map[string]int{}, nodes: map[string]*yamlv3.Node{}, names: map[string]bool{}}
+	a.count(&doc)
+	a.replace(&doc, "")
+
+	var buf bytes.Buffer
+	enc := yamlv3.NewEncoder(&buf)
+	enc.SetIndent(DefaultIndent)
+	if err = enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("%w: unable to marshal anchored yaml", err)
+	}
+	if err = enc.Close(); err != nil {
+		return nil, fmt.Errorf("%w: unable to marshal anchored yaml", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// anchors - finds repeated yaml subtrees by their canonical form.
+type anchors struct {
+	// counts - number of occurrences of subtree not nested into other occurrences of the same subtree.
+	counts map[string]int
+	// nodes - first occurrences of repeated subtree, anchored.
+	nodes map[string]*yamlv3.Node
+	// names - anchor names in use.
+	names map[string]bool
+}
+
+// count - counts occurrences of collection subtrees. Children of repeated subtrees are counted only in the first
+// occurrence as the rest become aliases.
+func (a *anchors) count(node *yamlv3.Node) {
+	if anchorable(node) {
+		key := canonical(node)
+		a.counts[key]++
+		if a.counts[key] > 1 {
+			return
+		}
+	}
+	for _, child := range node.Content {
+		a.count(child)
+	}
+}
+
+// replace - anchors first occurrences of repeated subtrees and replaces the rest with aliases. Name is the mapping
+// key of the node used as anchor name.
+func (a *anchors) replace(node *yamlv3.Node, name string) {
+	if anchorable(node) {
+		key := canonical(node)
+		if a.counts[key] > 1 {
+			if first, ok := a.nodes[key]; ok {
+				*node = yamlv3.Node{Kind: yamlv3.AliasNode, Value: first.Anchor, Alias: first}
+				return
+			}
+			node.Anchor = a.name(name)
+			a.nodes[key] = node
+		}
+	}
+	for i, child := range node.Content {
+		childName := name
+		if node.Kind == yamlv3.MappingNode {
+			if i%2 == 0 {
+				continue
+			}
+			childName = node.Content[i-1].Value
+		}
+		a.replace(child, childName)
+	}
+}
+
+// name - returns unique anchor name for given mapping key.
+func (a *anchors) name(key string) string {
+	base := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(",[]{}*&!|>'\"%@` \t", r) {
+			return '_'
+		}
+		return r
+	}, key)
+	if base == "" {
+		base = "item"
+	}
+	res := base
+	for i := 2; a.names[res]; i++ {
+		res = base + strconv.Itoa(i)
+	}
+	a.names[res] = true
+	return res
+}
+
+func anchorable(node *yamlv3.Node) bool {
+	return (node.Kind == yamlv3.MappingNode || node.Kind == yamlv3.SequenceNode) && len(node.Content) != 0
+}
+
+// canonical - returns string uniquely describing node subtree.
+func canonical(node *yamlv3.Node) string {
+	var b strings.Builder
+	var write func(n *yamlv3.Node)
+	write = func(n *yamlv3.Node) {
+		switch n.Kind {
+		case yamlv3.MappingNode:
+			b.WriteString("{")
+		case yamlv3.SequenceNode:
+			b.WriteString("[")
+		default:
+			b.WriteString(strconv.Quote(n.ShortTag() + ":" + n.Value))
+		}
+		for _, child := range n.Content {
+			write(child)
+			b.WriteString(",")
+		}
+		switch n.Kind {
+		case yamlv3.MappingNode:
+			b.WriteString("}")
+		case yamlv3.SequenceNode:
+			b.WriteString("]")
+		}
+	}
+	write(node)
+	return b.String()
+}
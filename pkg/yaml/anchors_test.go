@@ -0,0 +1,67 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestMarshalAnchored(t *testing.T) {
+	resources := func() map[string]interface{} {
+		return map[string]interface{}{
+			"limits":   map[string]interface{}{"cpu": "500m", "memory": "128Mi"},
+			"requests": map[string]interface{}{"cpu": "10m"},
+		}
+	}
+	values := map[string]interface{}{
+		"web": map[string]interface{}{
+			"app":     map[string]interface{}{"resources": resources(), "tolerations": []interface{}{}},
+			"sidecar": map[string]interface{}{"resources": resources(), "tolerations": []interface{}{}, "enabled": true},
+		},
+		"worker": map[string]interface{}{
+			"resources": map[string]interface{}{"limits": map[string]interface{}{"cpu": "500m", "memory": "128Mi"}},
+			"script":    "echo a\necho b\n",
+		},
+	}
+	res, err := MarshalAnchored(values)
+	assert.NoError(t, err)
+	assert.Equal(t, `web:
+  app:
+    resources: &resources
+      limits: &limits
+        cpu: 500m
+        memory: 128Mi
+      requests:
+        cpu: 10m
+    tolerations: []
+  sidecar:
+    enabled: true
+    resources: *resources
+    tolerations: []
+worker:
+  resources:
+    limits: *limits
+  script: |
+    echo a
+    echo b
+`, string(res))
+
+	t.Run("aliases resolved to the same values", func(t *testing.T) {
+		var got map[string]interface{}
+		err = yaml.Unmarshal(res, &got)
+		assert.NoError(t, err)
+		var want map[string]interface{}
+		plain, _ := yaml.Marshal(values)
+		_ = yaml.Unmarshal(plain, &want)
+		assert.Equal(t, want, got)
+	})
+	t.Run("same anchor names made unique", func(t *testing.T) {
+		res, err := MarshalAnchored(map[string]interface{}{
+			"a": map[string]interface{}{"x": []interface{}{"1"}, "z": []interface{}{"1"}},
+			"b": map[string]interface{}{"x": []interface{}{"2"}, "z": []interface{}{"2"}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "a:\n  x: &x\n    - \"1\"\n  z: *x\nb:\n  x: &x2\n    - \"2\"\n  z: *x2\n", string(res))
+	})
+}
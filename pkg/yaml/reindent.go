@@ -0,0 +1,202 @@
+package yaml
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DefaultIndent - indentation width of yaml generated by helmify.
+const DefaultIndent = 2
+
+var (
+	// blockScalarRe - matches line starting yaml block scalar, e.g. 'key: |-' or '- >2'.
+	blockScalarRe = regexp.MustCompile(`(^-|:|\s-)\s+[|>]([0-9]?)[+-]?([0-9]?)\s*$`)
+	// nindentRe - matches helm indent and nindent functions with their width argument.
+	nindentRe = regexp.MustCompile(`\b(n?indent) ([0-9]+)\b`)
+)
+
+// column - original column of yaml node and its column after reindent.
+type column struct {
+	orig, new int
+}
+
+// reindenter - converts yaml nodes indentation line by line keeping track of parents columns.
+type reindenter struct {
+	width   int
+	parents []column
+}
+
+// Reindent - changes indentation width of given yaml or Helm template generated with DefaultIndent to given width.
+// Nested mappings are indented by width, compact sequences are kept aligned with their keys and content of block
+// scalars keeps its relative indentation. Widths of indent and nindent template functions are converted to match
+// the new columns.
+func Reindent(content []byte, width int) []byte {
+	if width <= 0 || width == DefaultIndent {
+		return content
+	}
+	r := &reindenter{width: width}
+	lines := joinActions(strings.Split(string(content), "\n"))
+	// lines indented deeper than parent are block scalar content or continuation of multi-line scalar value
+	// and are shifted keeping their relative indentation.
+	parent, shift := -1, 0
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if parent >= 0 {
+			if trimmed == "" {
+				continue
+			}
+			if indent > parent {
+				lines[i] = strings.Repeat(" ", max(indent+shift, 0)) + shiftIndents(trimmed, shift)
+				continue
+			}
+			parent = -1
+		}
+		switch {
+		case trimmed == "":
+			continue
+		case strings.HasPrefix(trimmed, "---"):
+			r.parents = nil
+			continue
+		case strings.HasPrefix(trimmed, "{{"), strings.HasPrefix(trimmed, "#"), strings.HasPrefix(line, "\t"):
+			// template actions and comments are not yaml nodes, only their indent widths are converted
+			if !strings.HasPrefix(line, "\t") {
+				line = strings.Repeat(" ", r.lookup(indent)) + trimmed
+				indent = len(line) - len(trimmed)
+			}
+			lines[i] = line[:indent] + nindentRe.ReplaceAllStringFunc(trimmed, func(match string) string {
+				fn, n := splitIndent(match)
+				return fn + " " + strconv.Itoa(r.lookup(n))
+			})
+			continue
+		}
+		newIndent := r.push(indent)
+		res := trimmed
+		// compact sequence item content, e.g. '- name: a', is aligned with the following keys of the item
+		nodeIndent, nodeNew := indent, newIndent
+		for strings.HasPrefix(res[nodeIndent-indent:], "- ") {
+			nodeIndent, nodeNew = nodeIndent+2, nodeNew+2
+			r.parents = append(r.parents, column{orig: nodeIndent, new: nodeNew})
+		}
+		res = nindentRe.ReplaceAllStringFunc(res, func(match string) string {
+			fn, n := splitIndent(match)
+			if fn == "indent" {
+				// inline indent is relative to the line column
+				return fn + " " + strconv.Itoa(max(n+newIndent-indent, 0))
+			}
+			return fn + " " + strconv.Itoa(r.lookup(n))
+		})
+		if m := blockScalarRe.FindStringSubmatch(res); m != nil {
+			parent = indent
+			base := -1
+			if indicator := m[2] + m[3]; indicator != "" {
+				// explicit indentation indicator is relative to the parent column
+				d, _ := strconv.Atoi(indicator)
+				base = indent + d
+				at := strings.LastIndex(res, indicator)
+				res = res[:at] + strconv.Itoa(width) + res[at+len(indicator):]
+			}
+			if base < 0 {
+				base = blockContentIndent(lines[i+1:], indent)
+			}
+			shift = newIndent + width - base
+		} else if hasScalarValue(res[nodeIndent-indent:]) {
+			parent, shift = nodeIndent, nodeNew-nodeIndent
+		}
+		lines[i] = strings.Repeat(" ", newIndent) + res
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
+
+// joinActions - joins template actions wrapped by yaml serializer to single lines, so action arguments are not
+// lost with the original indentation, e.g. '{{ toYaml .Values.a | nindent\n    10 }}'.
+func joinActions(lines []string) []string {
+	res := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		for strings.Count(line, "{{") > strings.Count(line, "}}") && i+1 < len(lines) {
+			i++
+			line += " " + strings.TrimLeft(lines[i], " ")
+		}
+		res = append(res, line)
+	}
+	return res
+}
+
+// hasScalarValue - reports whether given yaml node line has scalar value which may continue on the next lines,
+// e.g. 'key: value' or plain sequence item, but not 'key:' with nested node.
+func hasScalarValue(node string) bool {
+	key := node
+	if strings.HasPrefix(node, "'") || strings.HasPrefix(node, `"`) {
+		end := strings.Index(node[1:], node[:1])
+		if end < 0 {
+			return true
+		}
+		key = node[end+2:]
+	}
+	if strings.HasSuffix(key, ":") {
+		return false
+	}
+	_, value, isMapping := strings.Cut(key, ": ")
+	return !isMapping || strings.TrimSpace(value) != ""
+}
+
+// push - returns new column of yaml node at given original column and stores it as the deepest parent.
+func (r *reindenter) push(indent int) int {
+	for len(r.parents) != 0 && r.parents[len(r.parents)-1].orig > indent {
+		r.parents = r.parents[:len(r.parents)-1]
+	}
+	newIndent := r.lookup(indent)
+	if len(r.parents) != 0 && r.parents[len(r.parents)-1].orig == indent {
+		r.parents = r.parents[:len(r.parents)-1]
+	}
+	r.parents = append(r.parents, column{orig: indent, new: newIndent})
+	return newIndent
+}
+
+// lookup - returns new column for given original column relative to the closest parent.
+func (r *reindenter) lookup(indent int) int {
+	parent := column{}
+	for i := len(r.parents) - 1; i >= 0; i-- {
+		if r.parents[i].orig <= indent {
+			parent = r.parents[i]
+			break
+		}
+	}
+	diff := indent - parent.orig
+	return parent.new + diff/DefaultIndent*r.width + diff%DefaultIndent
+}
+
+// blockContentIndent - returns indentation of the first block scalar content line. Defaults to the parent child
+// column for empty blocks.
+func blockContentIndent(lines []string, parent int) int {
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" {
+			continue
+		}
+		if indent := len(line) - len(trimmed); indent > parent {
+			return indent
+		}
+		break
+	}
+	return parent + DefaultIndent
+}
+
+// shiftIndents - adds given shift to all indent and nindent widths in given line.
+func shiftIndents(line string, shift int) string {
+	if !strings.Contains(line, "indent") {
+		return line
+	}
+	return nindentRe.ReplaceAllStringFunc(line, func(match string) string {
+		fn, n := splitIndent(match)
+		return fn + " " + strconv.Itoa(max(n+shift, 0))
+	})
+}
+
+func splitIndent(match string) (string, int) {
+	fn, width, _ := strings.Cut(match, " ")
+	n, _ := strconv.Atoi(width)
+	return fn, n
+}
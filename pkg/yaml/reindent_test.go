@@ -0,0 +1,100 @@
+package yaml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+const reindentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "chart.fullname" . }}-web
+  labels:
+  {{- include "chart.labels" . | nindent 4 }}
+spec:
+  template:
+    spec:
+      containers:
+      - args:
+        - --debug
+        env:
+        - name: A
+          value: b
+        image: nginx
+      nodeSelector: {{- toYaml .Values.web.nodeSelector | nindent 8 }}
+      securityContext: {{- toYaml .Values.web.podSecurityContext | nindent
+        8 }}
+  ports:
+	{{- .Values.web.ports | toYaml | nindent 2 -}}
+---
+data:
+  config.yaml: |
+    server:
+      port: 8080
+  script: {{ .Values.cfg.script | toYaml | indent 1 }}
+  indented: |2-
+      first line indented
+    second
+  next: value`
+
+func TestReindent(t *testing.T) {
+	t.Run("default width kept", func(t *testing.T) {
+		assert.Equal(t, reindentTemplate, string(Reindent([]byte(reindentTemplate), DefaultIndent)))
+	})
+	t.Run("width 4", func(t *testing.T) {
+		assert.Equal(t, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+    name: {{ include "chart.fullname" . }}-web
+    labels:
+    {{- include "chart.labels" . | nindent 8 }}
+spec:
+    template:
+        spec:
+            containers:
+            - args:
+              - --debug
+              env:
+              - name: A
+                value: b
+              image: nginx
+            nodeSelector: {{- toYaml .Values.web.nodeSelector | nindent 16 }}
+            securityContext: {{- toYaml .Values.web.podSecurityContext | nindent 16 }}
+    ports:
+	{{- .Values.web.ports | toYaml | nindent 4 -}}
+---
+data:
+    config.yaml: |
+        server:
+          port: 8080
+    script: {{ .Values.cfg.script | toYaml | indent 3 }}
+    indented: |4-
+          first line indented
+        second
+    next: value`, string(Reindent([]byte(reindentTemplate), 4)))
+	})
+	t.Run("yaml preserved", func(t *testing.T) {
+		in := `a:
+  b:
+  - c: 1
+    d:
+    - e
+    - - f
+  text: |-
+    line 1
+      line 2
+  description: type of condition in CamelCase.
+    --- Many values are consistent
+    - across resources like Available
+  quoted: 'first line
+    # second: line'
+z: 1
+`
+		var want, got interface{}
+		assert.NoError(t, yaml.Unmarshal([]byte(in), &want))
+		assert.NoError(t, yaml.Unmarshal(Reindent([]byte(in), 3), &got))
+		assert.Equal(t, want, got)
+	})
+}
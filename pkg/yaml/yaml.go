@@ -3,6 +3,7 @@ package yaml
 import (
 	"bytes"
 
+	"github.com/arttor/helmify/pkg/format"
 	"sigs.k8s.io/yaml"
 )
 
@@ -26,3 +27,13 @@ func Marshal(object interface{}, indent int) (string, error) {
 	objectBytes = bytes.TrimRight(objectBytes, "\n ")
 	return string(objectBytes), nil
 }
+
+// MarshalTemplated marshals object containing helm templates to yaml string with indentation.
+// Unlike Marshal, it removes single quotes yaml marshaller adds around template values.
+func MarshalTemplated(object interface{}, indent int) (string, error) {
+	res, err := Marshal(object, indent)
+	if err != nil {
+		return "", err
+	}
+	return format.UnquoteTemplates(res), nil
+}
@@ -44,3 +44,60 @@ func TestIndent(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalTemplated(t *testing.T) {
+	tests := []struct {
+		name   string
+		object interface{}
+		indent int
+		want   string
+	}{
+		{
+			name:   "template unquoted",
+			object: map[string]interface{}{"replicas": "{{ .Values.web.replicas }}"},
+			want:   "replicas: {{ .Values.web.replicas }}",
+		},
+		{
+			name:   "wildcard quoted",
+			object: map[string]interface{}{"host": "*"},
+			want:   "host: '*'",
+		},
+		{
+			name:   "apostrophe kept",
+			object: map[string]interface{}{"description": "it's"},
+			want:   "description: it's",
+		},
+		{
+			name:   "braces quoted",
+			object: map[string]interface{}{"query": `{job="web"}`},
+			want:   `query: '{job="web"}'`,
+		},
+		{
+			name:   "escaped quote kept",
+			object: map[string]interface{}{"description": "Don't use: legacy"},
+			want:   "description: 'Don''t use: legacy'",
+		},
+		{
+			name:   "quoted word kept",
+			object: map[string]interface{}{"args": []interface{}{"echo 'done'"}},
+			want:   "args:\n- echo 'done'",
+		},
+		{
+			name:   "indented",
+			object: map[string]interface{}{"spec": map[string]interface{}{"name": "{{ include \"app.fullname\" . }}-web"}},
+			indent: 2,
+			want:   "  spec:\n    name: {{ include \"app.fullname\" . }}-web",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalTemplated(tt.object, tt.indent)
+			if err != nil {
+				t.Fatalf("MarshalTemplated() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("MarshalTemplated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}